@@ -2,11 +2,20 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"net"
 	"os"
 	"os/signal"
-	"syscall"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/eadydb/zephyr/internal/config"
 	"github.com/eadydb/zephyr/internal/registry"
@@ -27,7 +36,12 @@ type App struct {
 	registry      plugin.ToolRegistry
 	pluginManager *plugin.PluginManager
 	mcpServer     *server.Server
-	transport     transport.TransportAdapter
+	auditSink     server.AuditSink
+	transports    []transport.TransportAdapter
+	transportsMu  sync.RWMutex // guards transports across reloads
+
+	// Set once startup plugin loading completes; transports report it on their readiness probes
+	ready atomic.Bool
 
 	// Configuration management
 	configPath    string
@@ -36,6 +50,20 @@ type App struct {
 	// Runtime context
 	ctx    context.Context
 	cancel context.CancelFunc
+
+	// The monitoring server has its own context so it can outlive the app
+	// context during shutdown; monitoringDone closes once it has fully stopped
+	monitoringCancel context.CancelFunc
+	monitoringDone   chan struct{}
+
+	// Non-critical plugins loaded in the background once the app runs
+	backgroundPlugins []string
+
+	// Probe each loaded plugin with its declared self-test input
+	selfTest bool
+
+	// Open log file when logging to a file
+	logFile io.Closer
 }
 
 // AppOptions holds optional configuration for the app
@@ -44,6 +72,13 @@ type AppOptions struct {
 	LogLevel        string
 	LogFormat       string
 	EnableHotReload bool
+	WorkDir         string // overrides server.workdir and applies before the config is read
+	SelfTest        bool   // run plugin self-tests after loading; critical failures abort startup
+
+	// Log file settings override the logging configuration when set
+	LogFile    string
+	LogMaxSize int           // megabytes before the log file is rotated
+	LogMaxAge  time.Duration // how long rotated log files are kept
 }
 
 // New creates a new application instance
@@ -64,17 +99,41 @@ func New(name, version string, opts *AppOptions) (*App, error) {
 func (a *App) initialize(opts *AppOptions) error {
 	// Setup context
 	a.ctx, a.cancel = context.WithCancel(context.Background())
+	a.selfTest = opts != nil && opts.SelfTest
 
 	// Setup logging
 	if err := a.setupLogging(opts); err != nil {
 		return fmt.Errorf("failed to setup logging: %w", err)
 	}
 
+	// Change to the working directory from the command line before reading the config
+	if opts != nil && opts.WorkDir != "" {
+		if err := os.Chdir(opts.WorkDir); err != nil {
+			return fmt.Errorf("failed to change working directory: %w", err)
+		}
+	}
+
 	// Load configuration
 	if err := a.loadConfig(opts); err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	// Otherwise apply the configured working directory, keeping the config path valid
+	if (opts == nil || opts.WorkDir == "") && a.config.Server.WorkDir != "" {
+		if err := a.changeWorkDir(a.config.Server.WorkDir); err != nil {
+			return fmt.Errorf("failed to change working directory: %w", err)
+		}
+	}
+
+	// Reconfigure logging now that the logging configuration is known
+	if err := a.setupLogging(opts); err != nil {
+		return fmt.Errorf("failed to setup logging: %w", err)
+	}
+
+	if workDir, err := os.Getwd(); err == nil {
+		a.logger.Info("Working directory", "path", workDir)
+	}
+
 	// Setup configuration hot reload if enabled
 	if opts != nil && opts.EnableHotReload {
 		if err := a.setupConfigWatcher(); err != nil {
@@ -106,13 +165,16 @@ func (a *App) setupLogging(opts *AppOptions) error {
 		}
 	}
 
+	output, err := a.logOutput(opts)
+	if err != nil {
+		return err
+	}
+
 	var handler slog.Handler
 	if opts != nil && opts.LogFormat == "json" {
-		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level: logLevel,
-		})
+		handler = a.jsonHandler(output, logLevel)
 	} else {
-		handler = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		handler = slog.NewTextHandler(output, &slog.HandlerOptions{
 			Level: logLevel,
 		})
 	}
@@ -123,6 +185,65 @@ func (a *App) setupLogging(opts *AppOptions) error {
 	return nil
 }
 
+// logOutput opens the log destination: the --log-file flag, otherwise the
+// configured output. Until the configuration is loaded logs go to stdout.
+func (a *App) logOutput(opts *AppOptions) (io.Writer, error) {
+	output := "stdout"
+	var file string
+	var maxSize int
+	var maxAge time.Duration
+	if a.config != nil {
+		output = a.config.Logging.Output
+		file = a.config.Logging.File
+		maxSize = a.config.Logging.MaxSize
+		maxAge = a.config.Logging.MaxAge
+	}
+
+	if opts != nil {
+		if opts.LogFile != "" {
+			output, file = "file", opts.LogFile
+		}
+		if opts.LogMaxSize > 0 {
+			maxSize = opts.LogMaxSize
+		}
+		if opts.LogMaxAge > 0 {
+			maxAge = opts.LogMaxAge
+		}
+	}
+
+	// Release a file opened by an earlier call
+	if err := a.closeLogFile(); err != nil {
+		return nil, err
+	}
+
+	switch output {
+	case "stderr":
+		return os.Stderr, nil
+	case "file":
+		if file == "" {
+			return nil, fmt.Errorf("log file is required when logging to a file")
+		}
+		f, err := openRotatingFile(file, int64(maxSize)*1024*1024, maxAge)
+		if err != nil {
+			return nil, err
+		}
+		a.logFile = f
+		return f, nil
+	default:
+		return os.Stdout, nil
+	}
+}
+
+// closeLogFile closes the log file, if logging to one
+func (a *App) closeLogFile() error {
+	if a.logFile == nil {
+		return nil
+	}
+	err := a.logFile.Close()
+	a.logFile = nil
+	return err
+}
+
 // loadConfig loads application configuration
 func (a *App) loadConfig(opts *AppOptions) error {
 	configPath := "config.yaml"
@@ -141,10 +262,26 @@ func (a *App) loadConfig(opts *AppOptions) error {
 	return nil
 }
 
+// changeWorkDir changes the process working directory, first making the config path absolute
+func (a *App) changeWorkDir(dir string) error {
+	configPath, err := filepath.Abs(a.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve config path: %w", err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		return err
+	}
+
+	a.configPath = configPath
+	return nil
+}
+
 // setupConfigWatcher initializes the configuration file watcher
 func (a *App) setupConfigWatcher() error {
 	watcher, err := config.NewWatcher(a.configPath, &config.WatcherOptions{
-		Logger: a.logger,
+		Logger:              a.logger,
+		StopOnCallbackError: a.config.Server.ReloadStopOnError,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create config watcher: %w", err)
@@ -163,6 +300,13 @@ func (a *App) setupConfigWatcher() error {
 func (a *App) onConfigReload(newConfig *config.Config) error {
 	a.logger.Info("Processing configuration reload")
 
+	// Transports are bound at startup; restart them when the protocol list
+	// changes and keep the running settings when that is not possible
+	if err := a.reloadTransports(a.config, newConfig); err != nil {
+		a.logger.Warn("Keeping current transport configuration", "reason", err)
+		newConfig.Transport = a.config.Transport
+	}
+
 	// Update app config reference
 	a.config = newConfig
 
@@ -183,35 +327,113 @@ func (a *App) initializeComponents() error {
 		"name", a.name,
 		"version", a.version)
 
+	// Memory statistics snapshots are shared by the metrics collector and plugins
+	plugin.SetMemStatsTTL(a.config.Monitoring.MemStatsTTL)
+
 	// Create metrics collector
-	a.metrics = server.NewMetricsCollector()
+	a.metrics = server.NewMetricsCollectorWithOptions(&server.MetricsOptions{
+		HistogramBuckets:  a.config.Monitoring.HistogramBuckets,
+		ShutdownTimeout:   a.config.Monitoring.ShutdownTimeout,
+		MetricsPath:       a.config.Monitoring.Endpoints.Metrics,
+		HealthPath:        a.config.Monitoring.Endpoints.Health,
+		MaxGoroutines:     a.config.Monitoring.MaxGoroutines,
+		HealthHistorySize: a.config.Monitoring.HealthHistorySize,
+		PProf:             a.config.Monitoring.PProfEnabled,
+		CORSOrigins:       a.monitoringCORSOrigins(),
+	})
+
+	// Restore cumulative counters from the previous run
+	if snapshot := a.config.Monitoring.Snapshot; snapshot.Enabled && snapshot.Restore {
+		if err := a.metrics.LoadSnapshot(snapshot.File); err != nil {
+			a.logger.Warn("Failed to restore metrics snapshot", "file", snapshot.File, "error", err)
+		} else {
+			a.logger.Info("Restored metrics snapshot", "file", snapshot.File)
+		}
+	}
 
 	// Create registry
 	a.registry = registry.NewRegistry(&a.config.Plugins)
 
 	// Create and setup plugin manager
-	a.pluginManager = plugin.NewPluginManager("./plugins", a.registry)
+	a.pluginManager = plugin.NewPluginManagerWithOptions("./plugins", a.registry, &plugin.PluginManagerOptions{
+		OpenTimeout:      a.config.Plugins.Loading.OpenTimeout,
+		LifecycleTimeout: a.config.Plugins.Loading.LifecycleTimeout,
+		Recursive:        a.config.Plugins.Discovery.Recursive,
+		MaxDepth:         a.config.Plugins.Discovery.MaxDepth,
+		MaxPlugins:       a.config.Plugins.Discovery.MaxPlugins,
+		LoadConcurrency:  a.config.Plugins.Loading.Concurrency,
+	})
 	if err := a.setupPlugins(); err != nil {
 		return fmt.Errorf("failed to setup plugins: %w", err)
 	}
+	a.metrics.SetPluginManager(a.pluginManager)
+	a.metrics.SetToolRegistry(a.registry)
+
+	// Create audit sink
+	auditSink, err := a.createAuditSink()
+	if err != nil {
+		return fmt.Errorf("failed to create audit sink: %w", err)
+	}
+	a.auditSink = auditSink
 
 	// Create MCP server
-	a.mcpServer = server.NewWithMetrics(a.name, a.version, a.registry, a.metrics)
+	instructions, err := a.config.Server.LoadInstructions()
+	if err != nil {
+		return fmt.Errorf("failed to load server instructions: %w", err)
+	}
+	a.mcpServer = server.NewWithOptions(a.name, a.version, a.registry, &server.ServerOptions{
+		Metrics:      a.metrics,
+		Prompts:      a.config.Server.Capabilities.Prompts,
+		Logging:      a.config.Server.Capabilities.Logging,
+		Instructions: instructions,
+	})
+	a.mcpServer.SetAuditSink(a.auditSink)
+
+	redactor, err := server.NewRedactor(a.config.Security.RedactPatterns)
+	if err != nil {
+		return fmt.Errorf("failed to create redactor: %w", err)
+	}
+	a.mcpServer.SetRedactor(redactor)
+	a.mcpServer.SetPrettyJSON(a.config.Server.PrettyJSON)
+	a.mcpServer.SetResultResources(a.config.Server.ResourceThreshold, a.config.Server.ResourceTTL)
+	a.mcpServer.SetRateLimits(a.globalRateLimit(), a.toolRateLimits())
+	a.mcpServer.SetResponseCache(a.toolCacheTTLs(), a.config.Server.CacheMaxEntries)
+	a.mcpServer.SetStructuredOutput(a.toolStructuredOutput())
+	if len(a.config.Server.Middleware) > 0 {
+		if err := a.mcpServer.SetMiddleware(a.config.Server.Middleware); err != nil {
+			return fmt.Errorf("failed to configure tool middleware: %w", err)
+		}
+	}
+	a.mcpServer.SetMemoryBudget(uint64(a.config.Security.Memory.MaxCallBytes), a.config.Security.Memory.Reject)
 	if err := a.mcpServer.Start(); err != nil {
 		return fmt.Errorf("failed to start MCP server: %w", err)
 	}
 
-	// Create transport
-	transportAdapter, err := transport.CreateTransportFromFullConfig(a.config, a.mcpServer.GetMCPServer())
+	// Create one transport per enabled protocol
+	transports, err := transport.CreateTransportsFromFullConfig(a.config, a.mcpServer.GetMCPServer())
 	if err != nil {
 		return fmt.Errorf("failed to create transport: %w", err)
 	}
-	a.transport = transportAdapter
+	a.transports = transports
+	a.metrics.SetTransportHealth(a.transportHealth)
 
 	return nil
 }
 
-// setupPlugins handles plugin discovery and loading
+// createAuditSink builds the tool-call audit sink selected in the configuration
+func (a *App) createAuditSink() (server.AuditSink, error) {
+	switch a.config.Security.Audit.Sink {
+	case "slog":
+		return server.NewSlogAuditSink(a.logger), nil
+	case "file":
+		return server.NewFileAuditSink(a.config.Security.Audit.File)
+	default:
+		return server.NopAuditSink{}, nil
+	}
+}
+
+// setupPlugins handles plugin discovery and loading. Critical plugins are loaded
+// synchronously and abort startup on failure; the rest are deferred to Run.
 func (a *App) setupPlugins() error {
 	a.logger.Info("Starting plugin discovery", "directories", []string{"./plugins"})
 
@@ -220,26 +442,165 @@ func (a *App) setupPlugins() error {
 		return err
 	}
 
-	if err := a.pluginManager.LoadAllPlugins(); err != nil {
-		a.logger.Warn("Some plugins failed to load", "error", err)
+	var critical []string
+	a.backgroundPlugins = nil
+	for name, metadata := range a.pluginManager.DiscoveredPlugins() {
+		if !a.isPluginEnabled(name, metadata) {
+			a.logger.Info("Skipping disabled plugin", "name", name, "enabled_default", metadata.EnabledDefault)
+			continue
+		}
+
+		isCritical := a.isCriticalPlugin(name, metadata) || a.config.Plugins.Loading.FailOnLoadError
+		if !a.pluginManager.IsBuilt(name) {
+			if isCritical {
+				return fmt.Errorf("critical plugin %s is not built", name)
+			}
+			a.logger.Info("Skipping plugin that is not built", "name", name)
+			continue
+		}
+
+		if isCritical {
+			critical = append(critical, name)
+		} else {
+			a.backgroundPlugins = append(a.backgroundPlugins, name)
+		}
 	}
 
-	// Log plugin status
-	pluginStatus := a.pluginManager.ListPlugins()
-	var loadedPlugins []string
-	for name, status := range pluginStatus {
-		if status.Loaded {
-			loadedPlugins = append(loadedPlugins, name)
+	if len(critical) > 0 {
+		a.logger.Info("Loading critical plugins", "plugins", critical)
+		if err := a.pluginManager.LoadPlugins(critical); err != nil {
+			return fmt.Errorf("critical plugin failed to load: %w", err)
+		}
+		if a.selfTest {
+			if err := a.selfTestPlugins(critical); err != nil {
+				return fmt.Errorf("critical plugin failed its self-test: %w", err)
+			}
+		}
+	}
+
+	// In lazy mode the remaining plugins are registered as stubs and load on first call
+	if a.config.Plugins.Loading.Lazy && len(a.backgroundPlugins) > 0 {
+		if err := a.pluginManager.RegisterLazyPlugins(a.backgroundPlugins); err != nil {
+			a.logger.Warn("Some lazy plugins failed to register", "error", err)
 		}
+		a.logger.Info("Registered lazy plugins", "plugins", a.backgroundPlugins)
+		a.backgroundPlugins = nil
 	}
 
+	// Log plugin status
+	loadedPlugins, loadTime := a.loadedPluginTimings(critical)
+
 	a.logger.Info("Plugin discovery completed",
 		"tool_count", len(loadedPlugins),
-		"tools", loadedPlugins)
+		"tools", loadedPlugins,
+		"deferred", a.backgroundPlugins,
+		"total_load_time", loadTime)
 
 	return nil
 }
 
+// globalRateLimit returns the configured requests per minute shared by all tools, or 0 if disabled
+func (a *App) globalRateLimit() int {
+	if !a.config.Security.RateLimit.Enabled {
+		return 0
+	}
+	return a.config.Security.RateLimit.RequestsPerMinute
+}
+
+// toolRateLimits returns the per-tool requests-per-minute overrides
+func (a *App) toolRateLimits() map[string]int {
+	limits := make(map[string]int)
+	for name, toolConfig := range a.config.Plugins.Tools {
+		if toolConfig.RateLimit > 0 {
+			limits[name] = toolConfig.RateLimit
+		}
+	}
+	return limits
+}
+
+// monitoringCORSOrigins returns the origins allowed to read the monitoring endpoints, or nil when CORS is off
+func (a *App) monitoringCORSOrigins() []string {
+	if !a.config.Monitoring.CORSEnabled {
+		return nil
+	}
+	if len(a.config.Monitoring.CORSOrigins) == 0 {
+		return []string{"*"}
+	}
+	return a.config.Monitoring.CORSOrigins
+}
+
+// toolCacheTTLs returns the response cache TTL of each tool that opted in to caching
+func (a *App) toolCacheTTLs() map[string]time.Duration {
+	ttls := make(map[string]time.Duration)
+	for name, toolConfig := range a.config.Plugins.Tools {
+		// Validation has already rejected malformed TTLs
+		if ttl, _ := toolConfig.CacheTTL(); ttl > 0 {
+			ttls[name] = ttl
+		}
+	}
+	return ttls
+}
+
+// toolStructuredOutput returns the tools whose JSON results are returned parsed
+func (a *App) toolStructuredOutput() map[string]bool {
+	tools := make(map[string]bool)
+	for name, toolConfig := range a.config.Plugins.Tools {
+		// Validation has already rejected non-boolean values
+		if enabled, _ := toolConfig.StructuredOutput(); enabled {
+			tools[name] = true
+		}
+	}
+	return tools
+}
+
+// isPluginEnabled reports whether a plugin should load. An enabled setting in
+// the configuration takes precedence over the plugin's enabled_default metadata.
+func (a *App) isPluginEnabled(name string, metadata plugin.PluginMetadata) bool {
+	return a.config.Plugins.Tools[name].IsEnabled(metadata.EnabledDefault)
+}
+
+// isCriticalPlugin reports whether a plugin is flagged critical in its metadata or the configuration
+func (a *App) isCriticalPlugin(name string, metadata plugin.PluginMetadata) bool {
+	if toolConfig, exists := a.config.Plugins.Tools[name]; exists && toolConfig.Critical {
+		return true
+	}
+	return metadata.Critical
+}
+
+// loadBackgroundPlugins loads the non-critical plugins. Tools become available
+// to clients as they register.
+func (a *App) loadBackgroundPlugins(names []string) {
+	if len(names) == 0 {
+		return
+	}
+
+	if err := a.pluginManager.LoadPlugins(names); err != nil {
+		a.logger.Warn("Some plugins failed to load", "error", err)
+	}
+
+	if a.selfTest {
+		a.selfTestPlugins(names)
+	}
+
+	loadedPlugins, loadTime := a.loadedPluginTimings(names)
+	a.logger.Info("Background plugin loading completed",
+		"plugins", loadedPlugins,
+		"total_load_time", loadTime)
+}
+
+// loadedPluginTimings returns which of the named plugins are loaded and their combined load time
+func (a *App) loadedPluginTimings(names []string) ([]string, time.Duration) {
+	var loaded []string
+	var total time.Duration
+	for _, name := range names {
+		if loadedPlugin, exists := a.pluginManager.GetPlugin(name); exists {
+			loaded = append(loaded, name)
+			total += loadedPlugin.LoadDuration()
+		}
+	}
+	return loaded, total
+}
+
 // Run starts the application and blocks until shutdown
 func (a *App) Run() error {
 	a.logger.Info("Starting application", "name", a.name, "version", a.version)
@@ -253,102 +614,412 @@ func (a *App) Run() error {
 
 	// Start monitoring server if enabled
 	if a.config.Monitoring.Enabled {
-		go a.startMonitoring()
+		a.monitoringDone = make(chan struct{})
+		monitoringCtx, monitoringCancel := context.WithCancel(context.Background())
+		a.monitoringCancel = monitoringCancel
+		go a.startMonitoring(monitoringCtx)
 	}
 
-	// Start transport
-	if err := a.transport.Start(a.ctx); err != nil {
-		return fmt.Errorf("failed to start transport: %w", err)
+	// Load non-critical plugins without delaying the transport, then report ready
+	go func() {
+		a.loadBackgroundPlugins(a.backgroundPlugins)
+		a.setReady()
+	}()
+
+	// Start transports; Shutdown stops any that already started
+	a.transportsMu.RLock()
+	err := a.startTransports(a.transports)
+	a.transportsMu.RUnlock()
+	if err != nil {
+		if shutdownErr := a.Shutdown(); shutdownErr != nil {
+			a.logger.Warn("Cleanup after failed start reported errors", "error", shutdownErr)
+		}
+		return err
 	}
 
 	// Setup graceful shutdown
 	return a.waitForShutdown()
 }
 
-// startMonitoring starts the monitoring server
-func (a *App) startMonitoring() {
+// setReady marks startup complete and reports it on every transport's readiness probe
+func (a *App) setReady() {
+	a.transportsMu.RLock()
+	defer a.transportsMu.RUnlock()
+
+	a.ready.Store(true)
+	for _, t := range a.transports {
+		if readiness, ok := t.(transport.ReadinessTransport); ok {
+			readiness.SetReady(true)
+		}
+	}
+	a.logger.Info("Application ready")
+}
+
+// inheritReadiness gives transports created by a reload the current readiness.
+// The caller holds transportsMu so setReady cannot run concurrently.
+func (a *App) inheritReadiness(transports []transport.TransportAdapter) {
+	for _, t := range transports {
+		if readiness, ok := t.(transport.ReadinessTransport); ok {
+			readiness.SetReady(a.ready.Load())
+		}
+	}
+}
+
+// setTransportsDraining makes every transport's readiness probe fail during shutdown
+func (a *App) setTransportsDraining() {
+	a.transportsMu.RLock()
+	defer a.transportsMu.RUnlock()
+
+	for _, t := range a.transports {
+		if readiness, ok := t.(transport.ReadinessTransport); ok {
+			readiness.SetDraining(true)
+		}
+	}
+}
+
+// startTransports starts each transport in order, stopping at the first failure
+func (a *App) startTransports(transports []transport.TransportAdapter) error {
+	for _, t := range transports {
+		if err := t.Start(a.ctx); err != nil {
+			a.logger.Error("Failed to start transport", "protocol", t.Name(), "error", err)
+			return fmt.Errorf("failed to start transport %s: %w", t.Name(), err)
+		}
+		if networkTransport, ok := t.(transport.NetworkTransport); ok {
+			a.logger.Info("Transport listening", "protocol", networkTransport.Name(), "address", networkTransport.Addr())
+		}
+	}
+	return nil
+}
+
+// reloadTransports restarts the transports when a reload changes the protocol
+// list. Stopping a network transport waits for its in-flight requests to
+// finish before the replacements bind. Other transport settings, and any
+// change involving stdio, need a server restart and are refused.
+func (a *App) reloadTransports(oldConfig, newConfig *config.Config) error {
+	oldProtocols := slices.Sorted(slices.Values(oldConfig.Transport.EnabledProtocols()))
+	newProtocols := slices.Sorted(slices.Values(newConfig.Transport.EnabledProtocols()))
+
+	if slices.Equal(oldProtocols, newProtocols) {
+		if !reflect.DeepEqual(oldConfig.Transport, newConfig.Transport) {
+			return fmt.Errorf("transport settings changed; restart the server to apply them")
+		}
+		return nil
+	}
+
+	if slices.Contains(oldProtocols, "stdio") || slices.Contains(newProtocols, "stdio") {
+		return fmt.Errorf("cannot switch transports from %v to %v at runtime: stdio is bound to the launching process; restart the server",
+			oldProtocols, newProtocols)
+	}
+
+	mcpServer := a.mcpServer.GetMCPServer()
+	replacements, err := transport.CreateTransportsFromFullConfig(newConfig, mcpServer)
+	if err != nil {
+		return fmt.Errorf("failed to create transports: %w", err)
+	}
+
+	a.transportsMu.Lock()
+	defer a.transportsMu.Unlock()
+
+	a.logger.Info("Restarting transports", "from", oldProtocols, "to", newProtocols)
+	if err := a.stopTransportsLocked(); err != nil {
+		a.logger.Warn("Transports did not stop cleanly", "error", err)
+	}
+
+	a.inheritReadiness(replacements)
+
+	if startErr := a.startTransports(replacements); startErr != nil {
+		// Bring the previous transports back rather than serving nothing
+		for _, t := range replacements {
+			t.Stop()
+		}
+		previous, err := transport.CreateTransportsFromFullConfig(oldConfig, mcpServer)
+		if err == nil {
+			a.inheritReadiness(previous)
+			err = a.startTransports(previous)
+		}
+		if err != nil {
+			a.transports = nil
+			return fmt.Errorf("%w; restoring the previous transports failed: %v", startErr, err)
+		}
+		a.transports = previous
+		return startErr
+	}
+
+	a.transports = replacements
+	return nil
+}
+
+// transportHealth reports whether each configured transport is healthy, keyed by protocol
+func (a *App) transportHealth() map[string]bool {
+	a.transportsMu.RLock()
+	defer a.transportsMu.RUnlock()
+
+	health := make(map[string]bool, len(a.transports))
+	for _, t := range a.transports {
+		health[t.Name()] = t.IsHealthy()
+	}
+	return health
+}
+
+// startMonitoring runs the monitoring server until ctx is cancelled
+func (a *App) startMonitoring(ctx context.Context) {
+	defer close(a.monitoringDone)
+
 	monitoringAddr := fmt.Sprintf("%s:%d", a.config.Monitoring.Host, a.config.Monitoring.Port)
 	a.logger.Info("Starting monitoring server", "address", monitoringAddr)
+	if a.config.Monitoring.PProfEnabled {
+		// There is no monitoring auth to gate profiling behind, so flag public binds
+		if host := a.config.Monitoring.Host; host != "localhost" && !net.ParseIP(host).IsLoopback() {
+			a.logger.Warn("Profiling endpoints exposed on a non-loopback address", "address", monitoringAddr)
+		}
+	}
 
-	if err := a.metrics.StartMetricsServer(a.ctx, monitoringAddr); err != nil {
+	if err := a.metrics.StartMetricsServer(ctx, monitoringAddr); err != nil {
 		a.logger.Error("Monitoring server error", "error", err)
 	}
 }
 
-// waitForShutdown waits for shutdown signal and performs graceful shutdown
+// waitForShutdown waits for shutdown signal and performs graceful shutdown.
+// On unix SIGHUP reloads the configuration and SIGUSR1 logs a diagnostic
+// dump, neither of which stops the application.
 func (a *App) waitForShutdown() error {
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	notifySignals(sigChan)
+	defer signal.Stop(sigChan)
 
 	a.logger.Info("Application is running. Press Ctrl+C to stop.")
 
-	// Wait for shutdown signal
-	sig := <-sigChan
-	a.logger.Info("Received shutdown signal", "signal", sig)
+	for sig := range sigChan {
+		if a.handleSignal(sig) {
+			continue
+		}
+		a.logger.Info("Received shutdown signal", "signal", sig)
+		return a.Shutdown()
+	}
 
 	return a.Shutdown()
 }
 
+// dumpDiagnostics logs current metrics, plugin status, and all goroutine stacks
+func (a *App) dumpDiagnostics() {
+	var metrics map[string]interface{}
+	if a.metrics != nil {
+		a.metrics.UpdateSystemMetrics()
+		metrics = a.metrics.GetMetrics()
+	}
+
+	var plugins map[string]plugin.PluginStatus
+	if a.pluginManager != nil {
+		plugins = a.pluginManager.ListPlugins()
+	}
+
+	a.logger.Info("Diagnostic dump",
+		"metrics", metrics,
+		"plugins", plugins,
+		"goroutine_count", runtime.NumGoroutine(),
+		"goroutines", string(goroutineStacks()))
+}
+
+// goroutineStacks returns the stack traces of all goroutines, growing the buffer until they fit
+func goroutineStacks() []byte {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// shutdownStep is one named stage of the shutdown sequence
+type shutdownStep struct {
+	name string
+	run  func() error
+}
+
 // Shutdown performs graceful shutdown of all components
 func (a *App) Shutdown() error {
 	a.logger.Info("Shutting down application...")
 
-	var shutdownErrors []error
-
-	// Cancel context for monitoring and other goroutines
-	a.cancel()
+	// The log file stays open for the final shutdown messages
+	defer a.closeLogFile()
 
-	// Stop configuration watcher
-	if a.configWatcher != nil {
-		if err := a.configWatcher.Stop(); err != nil {
-			a.logger.Error("Error stopping config watcher", "error", err)
+	var shutdownErrors []error
+	for _, step := range a.shutdownSteps() {
+		a.logger.Debug("Shutdown step", "step", step.name)
+		if err := step.run(); err != nil {
 			shutdownErrors = append(shutdownErrors, err)
 		}
 	}
 
-	// Stop transport
-	if a.transport != nil {
-		if err := a.transport.Stop(); err != nil {
-			a.logger.Error("Error stopping transport", "error", err)
-			shutdownErrors = append(shutdownErrors, err)
-		}
+	if len(shutdownErrors) > 0 {
+		a.logger.Error("Shutdown completed with errors", "error_count", len(shutdownErrors))
+		return fmt.Errorf("shutdown had %d errors: %w", len(shutdownErrors), errors.Join(shutdownErrors...))
 	}
 
-	// Unload all plugins gracefully
-	if a.pluginManager != nil {
-		pluginStatus := a.pluginManager.ListPlugins()
-		for name := range pluginStatus {
-			if err := a.pluginManager.UnloadPlugin(name); err != nil {
-				a.logger.Error("Error unloading plugin", "plugin", name, "error", err)
-				shutdownErrors = append(shutdownErrors, err)
+	a.logger.Info("Shutdown complete")
+	return nil
+}
+
+// shutdownSteps returns the shutdown sequence. Health checks report draining
+// from the first step; with monitoring.drain_last the monitoring server keeps
+// serving until every other component has stopped.
+func (a *App) shutdownSteps() []shutdownStep {
+	monitoring := shutdownStep{name: "monitoring", run: a.stopMonitoring}
+
+	steps := []shutdownStep{
+		{name: "drain", run: func() error {
+			if a.metrics != nil {
+				a.metrics.SetDraining(true)
 			}
+			a.setTransportsDraining()
+			// Cancel context for background goroutines
+			a.cancel()
+			return nil
+		}},
+	}
+	if !a.config.Monitoring.DrainLast {
+		steps = append(steps, monitoring)
+	}
+
+	steps = append(steps,
+		shutdownStep{name: "config watcher", run: a.stopConfigWatcher},
+		shutdownStep{name: "transports", run: a.stopTransports},
+		shutdownStep{name: "plugins", run: a.unloadPlugins},
+		shutdownStep{name: "mcp server", run: a.stopMCPServer},
+	)
+
+	if a.config.Monitoring.DrainLast {
+		steps = append(steps, monitoring)
+	}
+
+	return append(steps,
+		shutdownStep{name: "metrics snapshot", run: a.saveMetricsSnapshot},
+		shutdownStep{name: "audit sink", run: a.closeAuditSink},
+	)
+}
+
+// stopMonitoring stops the monitoring server and waits for it to release its port
+func (a *App) stopMonitoring() error {
+	if a.monitoringCancel != nil {
+		a.monitoringCancel()
+	}
+	if a.monitoringDone != nil {
+		<-a.monitoringDone
+		a.logger.Debug("Monitoring server stopped")
+	}
+	return nil
+}
+
+// stopConfigWatcher stops watching the configuration file
+func (a *App) stopConfigWatcher() error {
+	if a.configWatcher == nil {
+		return nil
+	}
+	if err := a.configWatcher.Stop(); err != nil {
+		a.logger.Error("Error stopping config watcher", "error", err)
+		return err
+	}
+	return nil
+}
+
+// stopTransports stops transports in reverse start order
+func (a *App) stopTransports() error {
+	a.transportsMu.Lock()
+	defer a.transportsMu.Unlock()
+	return a.stopTransportsLocked()
+}
+
+// stopTransportsLocked stops transports; the caller holds transportsMu
+func (a *App) stopTransportsLocked() error {
+	var errs []error
+	for i := len(a.transports) - 1; i >= 0; i-- {
+		t := a.transports[i]
+		if err := t.Stop(); err != nil {
+			a.logger.Error("Error stopping transport", "protocol", t.Name(), "error", err)
+			errs = append(errs, err)
 		}
 	}
+	return errors.Join(errs...)
+}
+
+// unloadPlugins unloads all loaded plugins gracefully
+func (a *App) unloadPlugins() error {
+	if a.pluginManager == nil {
+		return nil
+	}
 
-	// Stop MCP server
-	if a.mcpServer != nil {
-		if err := a.mcpServer.Stop(); err != nil {
-			a.logger.Error("Error stopping MCP server", "error", err)
-			shutdownErrors = append(shutdownErrors, err)
+	var errs []error
+	for name, status := range a.pluginManager.ListPlugins() {
+		if !status.Loaded {
+			continue
+		}
+		if err := a.pluginManager.UnloadPlugin(name); err != nil {
+			a.logger.Error("Error unloading plugin", "plugin", name, "error", err)
+			errs = append(errs, err)
 		}
 	}
+	return errors.Join(errs...)
+}
 
-	if len(shutdownErrors) > 0 {
-		a.logger.Error("Shutdown completed with errors", "error_count", len(shutdownErrors))
-		return fmt.Errorf("shutdown had %d errors", len(shutdownErrors))
+// stopMCPServer stops the MCP server
+func (a *App) stopMCPServer() error {
+	if a.mcpServer == nil {
+		return nil
+	}
+	if err := a.mcpServer.Stop(); err != nil {
+		a.logger.Error("Error stopping MCP server", "error", err)
+		return err
 	}
+	return nil
+}
 
-	a.logger.Info("Shutdown complete")
+// saveMetricsSnapshot persists final metrics when snapshots are enabled
+func (a *App) saveMetricsSnapshot() error {
+	snapshot := a.config.Monitoring.Snapshot
+	if !snapshot.Enabled || a.metrics == nil {
+		return nil
+	}
+	if err := a.metrics.SaveSnapshot(snapshot.File); err != nil {
+		a.logger.Error("Error saving metrics snapshot", "file", snapshot.File, "error", err)
+		return err
+	}
+	a.logger.Info("Saved metrics snapshot", "file", snapshot.File)
+	return nil
+}
+
+// closeAuditSink closes the audit sink if it holds resources
+func (a *App) closeAuditSink() error {
+	closer, ok := a.auditSink.(io.Closer)
+	if !ok {
+		return nil
+	}
+	if err := closer.Close(); err != nil {
+		a.logger.Error("Error closing audit sink", "error", err)
+		return err
+	}
 	return nil
 }
 
-// ReloadConfig manually triggers a configuration reload
+// ReloadConfig manually triggers a configuration reload. Without hot reload the
+// configuration file is loaded directly and applied through the same callback.
 func (a *App) ReloadConfig() error {
-	if a.configWatcher == nil {
-		return fmt.Errorf("configuration hot reload is not enabled")
+	return a.reloadConfig(config.ReloadTriggerManual)
+}
+
+// reloadConfig reloads the configuration, attributing the attempt to trigger
+func (a *App) reloadConfig(trigger config.ReloadTrigger) error {
+	if a.configWatcher != nil {
+		return a.configWatcher.Reload(trigger)
+	}
+
+	cfg, err := config.Load(a.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to reload configuration: %w", err)
 	}
 
-	return a.configWatcher.ReloadNow()
+	return a.onConfigReload(cfg)
 }
 
 // GetConfig returns the application configuration
@@ -0,0 +1,141 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// rotatedSuffixFormat names rotated log files so they sort by age
+const rotatedSuffixFormat = "20060102-150405.000"
+
+// rotatingFile is a log file that is rotated once it grows past maxSize bytes.
+// Rotated files keep a timestamp suffix and are removed once older than maxAge.
+type rotatingFile struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64         // 0 disables size-based rotation
+	maxAge  time.Duration // 0 keeps rotated files forever
+	file    *os.File
+	size    int64
+}
+
+// openRotatingFile opens or creates the log file at path for appending
+func openRotatingFile(path string, maxSize int64, maxAge time.Duration) (*rotatingFile, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create log directory: %w", err)
+		}
+	}
+
+	f := &rotatingFile{path: path, maxSize: maxSize, maxAge: maxAge}
+	if err := f.openLocked(); err != nil {
+		return nil, err
+	}
+	f.pruneLocked()
+	return f, nil
+}
+
+// openLocked opens the current log file. The caller must hold f.mu.
+func (f *rotatingFile) openLocked() error {
+	file, size, err := openLogFile(f.path)
+	if err != nil {
+		return err
+	}
+
+	f.file = file
+	f.size = size
+	return nil
+}
+
+// openLogFile opens a log file for appending and returns its current size
+func openLogFile(path string) (*os.File, int64, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, fmt.Errorf("failed to stat log file: %w", err)
+	}
+	return file, info.Size(), nil
+}
+
+// Write appends to the log file, rotating it first if the write would exceed maxSize
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotateLocked(); err != nil {
+			// Keep logging to the current file rather than losing the entry
+			fmt.Fprintf(os.Stderr, "log rotation failed: %v\n", err)
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// rotateLocked renames the current file aside and starts a new one. The old
+// file stays open until the new one is, so a failed rotation never leaves the
+// writer without a usable file. The caller must hold f.mu.
+func (f *rotatingFile) rotateLocked() error {
+	rotated := f.path + "." + time.Now().Format(rotatedSuffixFormat)
+	if err := os.Rename(f.path, rotated); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	file, size, err := openLogFile(f.path)
+	if err != nil {
+		// Put the current file back so later writes and rotations still use it
+		if restoreErr := os.Rename(rotated, f.path); restoreErr != nil {
+			return fmt.Errorf("%w (and failed to restore it: %v)", err, restoreErr)
+		}
+		return err
+	}
+
+	if err := f.file.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to close rotated log file: %v\n", err)
+	}
+	f.file = file
+	f.size = size
+
+	f.pruneLocked()
+	return nil
+}
+
+// pruneLocked removes rotated files older than maxAge. The caller must hold f.mu.
+func (f *rotatingFile) pruneLocked() {
+	if f.maxAge <= 0 {
+		return
+	}
+
+	matches, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return
+	}
+
+	cutoff := time.Now().Add(-f.maxAge)
+	for _, match := range matches {
+		// Only touch files this writer named
+		if _, err := time.Parse(rotatedSuffixFormat, match[len(f.path)+1:]); err != nil {
+			continue
+		}
+		if info, err := os.Stat(match); err == nil && info.ModTime().Before(cutoff) {
+			os.Remove(match)
+		}
+	}
+}
+
+// Close closes the current log file
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// ecsVersion is the Elastic Common Schema version the ecs JSON style follows
+const ecsVersion = "8.11.0"
+
+// ecsKeys maps the built-in slog keys to their Elastic Common Schema fields
+var ecsKeys = map[string]string{
+	slog.TimeKey:    "@timestamp",
+	slog.LevelKey:   "log.level",
+	slog.MessageKey: "message",
+	slog.SourceKey:  "log.origin",
+}
+
+// jsonReplaceAttr returns the ReplaceAttr hook that renames the built-in keys
+// of JSON log records for the style, then applies the configured renames. It
+// returns nil when records keep the slog key names.
+func jsonReplaceAttr(style string, keys map[string]string) func(groups []string, a slog.Attr) slog.Attr {
+	renames := make(map[string]string)
+	if style == "ecs" {
+		for key, name := range ecsKeys {
+			renames[key] = name
+		}
+	}
+	for key, name := range keys {
+		renames[key] = name
+	}
+	if len(renames) == 0 {
+		return nil
+	}
+
+	return func(groups []string, a slog.Attr) slog.Attr {
+		// Only the top-level built-in keys are renamed, never attributes in groups
+		if len(groups) > 0 {
+			return a
+		}
+		name, ok := renames[a.Key]
+		if !ok {
+			return a
+		}
+
+		// ECS expects lowercase level names
+		if a.Key == slog.LevelKey && style == "ecs" {
+			if level, ok := a.Value.Any().(slog.Level); ok {
+				return slog.String(name, strings.ToLower(level.String()))
+			}
+		}
+		a.Key = name
+		return a
+	}
+}
+
+// jsonHandler creates the JSON log handler for the configured style
+func (a *App) jsonHandler(output io.Writer, level slog.Level) slog.Handler {
+	var style string
+	var keys map[string]string
+	if a.config != nil {
+		style = a.config.Logging.JSONStyle
+		keys = a.config.Logging.JSONKeys
+	}
+
+	var handler slog.Handler = slog.NewJSONHandler(output, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: jsonReplaceAttr(style, keys),
+	})
+	if style == "ecs" {
+		handler = handler.WithAttrs([]slog.Attr{slog.String("ecs.version", ecsVersion)})
+	}
+	return handler
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eadydb/zephyr/pkg/plugin"
+)
+
+// selfTestPlugins calls each loaded plugin with the self-test input declared in
+// its plugin.json and logs the outcome. Plugins without one are skipped. Only
+// failures of critical plugins are returned.
+func (a *App) selfTestPlugins(names []string) error {
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+
+	var criticalErrs []error
+	for _, name := range sorted {
+		loaded, exists := a.pluginManager.GetPlugin(name)
+		if !exists {
+			continue
+		}
+		if loaded.Metadata.SelfTest == nil {
+			a.logger.Debug("Skipping plugin self-test, none declared", "name", name)
+			continue
+		}
+
+		start := time.Now()
+		err := a.runSelfTest(loaded)
+		if err == nil {
+			a.logger.Info("Plugin self-test passed", "name", name, "duration", time.Since(start))
+			continue
+		}
+
+		critical := a.isCriticalPlugin(name, loaded.Metadata)
+		a.logger.Error("Plugin self-test failed", "name", name, "critical", critical, "error", err)
+		if critical {
+			criticalErrs = append(criticalErrs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	return errors.Join(criticalErrs...)
+}
+
+// runSelfTest executes one self-test call within the request timeout
+func (a *App) runSelfTest(loaded *plugin.LoadedPlugin) error {
+	ctx, cancel := context.WithTimeout(a.ctx, a.config.Security.Timeout.Request)
+	defer cancel()
+
+	// Copy the input so a plugin that modifies its arguments cannot alter the metadata
+	input := make(map[string]interface{}, len(loaded.Metadata.SelfTest))
+	for key, value := range loaded.Metadata.SelfTest {
+		input[key] = value
+	}
+
+	result, err := loaded.Plugin.Execute(ctx, input)
+	if err != nil {
+		return err
+	}
+	if typed, ok := result.(*plugin.ToolResult); ok {
+		return typed.Err()
+	}
+	return nil
+}
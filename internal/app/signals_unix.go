@@ -0,0 +1,38 @@
+//go:build unix
+
+package app
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/eadydb/zephyr/internal/config"
+)
+
+// notifySignals relays the shutdown signals, SIGHUP and SIGUSR1 to c
+func notifySignals(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGUSR1)
+}
+
+// handleSignal acts on a signal that does not stop the application: SIGHUP
+// reloads the configuration and SIGUSR1 logs a diagnostic dump. It reports
+// whether the signal was handled.
+func (a *App) handleSignal(sig os.Signal) bool {
+	switch sig {
+	case syscall.SIGHUP:
+		a.logger.Info("Received reload signal", "signal", sig)
+		if err := a.reloadConfig(config.ReloadTriggerSignal); err != nil {
+			a.logger.Error("Configuration reload failed", "error", err)
+		} else {
+			a.logger.Info("Configuration reload succeeded")
+		}
+		return true
+	case syscall.SIGUSR1:
+		a.logger.Info("Received diagnostics signal", "signal", sig)
+		a.dumpDiagnostics()
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,18 @@
+package app
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifySignals relays the shutdown signals to c; Windows has no reload or
+// diagnostics signals
+func notifySignals(c chan<- os.Signal) {
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+}
+
+// handleSignal reports that every signal stops the application
+func (a *App) handleSignal(sig os.Signal) bool {
+	return false
+}
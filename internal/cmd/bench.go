@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eadydb/zephyr/pkg/mcp/server"
+	"github.com/eadydb/zephyr/pkg/plugin"
+	"github.com/spf13/cobra"
+)
+
+// toolCmd represents the tool command
+var toolCmd = &cobra.Command{
+	Use:   "tool",
+	Short: "Single tool commands",
+	Long:  `Commands that operate on a single tool provided by a Zephyr plugin.`,
+}
+
+// toolBenchCmd represents the tool bench subcommand
+var toolBenchCmd = &cobra.Command{
+	Use:   "bench <name>",
+	Short: "Benchmark a tool's throughput and latency",
+	Long: `Load a plugin and call its tool repeatedly from concurrent workers for a
+fixed duration, then report requests per second, latency percentiles, and the
+error rate. Calls go straight to the plugin, bypassing the MCP server.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runToolBench,
+}
+
+func init() {
+	rootCmd.AddCommand(toolCmd)
+	toolCmd.AddCommand(toolBenchCmd)
+
+	// Bench-specific flags
+	toolBenchCmd.Flags().String("plugins-dir", defaultPluginsDir, "directory containing plugins")
+	toolBenchCmd.Flags().String("args", "{}", "tool arguments as a JSON object")
+	toolBenchCmd.Flags().IntP("concurrency", "c", 1, "number of concurrent workers")
+	toolBenchCmd.Flags().DurationP("duration", "d", 10*time.Second, "how long to run the benchmark")
+}
+
+// benchWorkerResult holds what a single worker observed
+type benchWorkerResult struct {
+	latencies []time.Duration
+	errors    int
+}
+
+func runToolBench(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	pluginsDir, _ := cmd.Flags().GetString("plugins-dir")
+	rawArgs, _ := cmd.Flags().GetString("args")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	duration, _ := cmd.Flags().GetDuration("duration")
+
+	if concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+	if duration <= 0 {
+		return fmt.Errorf("duration must be positive")
+	}
+
+	var toolArgs map[string]interface{}
+	if err := json.Unmarshal([]byte(rawArgs), &toolArgs); err != nil {
+		return fmt.Errorf("invalid --args JSON: %w", err)
+	}
+
+	manager := plugin.NewPluginManager(pluginsDir, nil)
+	if err := manager.DiscoverPlugins(); err != nil {
+		return fmt.Errorf("failed to discover plugins: %w", err)
+	}
+	if err := manager.LoadPlugin(name); err != nil {
+		return fmt.Errorf("failed to load plugin %s: %w", name, err)
+	}
+	defer manager.UnloadPlugin(name)
+
+	loaded, ok := manager.GetPlugin(name)
+	if !ok {
+		return fmt.Errorf("plugin %s not loaded", name)
+	}
+
+	infof("Benchmarking %s with %d workers for %s\n", name, concurrency, duration)
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), duration)
+	defer cancel()
+
+	results := make([]benchWorkerResult, concurrency)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			results[worker] = benchWorker(ctx, cmd.Context(), loaded.Plugin, toolArgs)
+		}(i)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	// Merge worker results
+	var latencies []time.Duration
+	errors := 0
+	for _, result := range results {
+		latencies = append(latencies, result.latencies...)
+		errors += result.errors
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	printBenchReport(name, latencies, errors, elapsed)
+	return nil
+}
+
+// benchWorker calls the tool until ctx expires. Calls run under callCtx rather
+// than ctx, so the ones in flight at the deadline finish instead of counting as
+// errors. Each call gets a fresh copy of the arguments since tools may modify them.
+func benchWorker(ctx, callCtx context.Context, tool plugin.DynamicPlugin, args map[string]interface{}) benchWorkerResult {
+	var result benchWorkerResult
+	for ctx.Err() == nil {
+		callArgs := make(map[string]interface{}, len(args))
+		for k, v := range args {
+			callArgs[k] = v
+		}
+
+		callStart := time.Now()
+		output, err := tool.Execute(callCtx, callArgs)
+		result.latencies = append(result.latencies, time.Since(callStart))
+
+		if err == nil {
+			if typed, ok := output.(*plugin.ToolResult); ok {
+				err = typed.Err()
+			}
+		}
+		if err != nil {
+			result.errors++
+		}
+	}
+	return result
+}
+
+// printBenchReport prints throughput, error rate, and latency percentiles
+func printBenchReport(name string, latencies []time.Duration, errors int, elapsed time.Duration) {
+	requests := len(latencies)
+	if requests == 0 {
+		fmt.Printf("No calls to %s completed\n", name)
+		return
+	}
+
+	var total time.Duration
+	for _, latency := range latencies {
+		total += latency
+	}
+
+	fmt.Printf("Tool:         %s\n", name)
+	fmt.Printf("Requests:     %d\n", requests)
+	fmt.Printf("Duration:     %s\n", elapsed.Round(time.Millisecond))
+	fmt.Printf("Requests/sec: %.2f\n", float64(requests)/elapsed.Seconds())
+	fmt.Printf("Errors:       %d (%.2f%%)\n", errors, float64(errors)/float64(requests)*100)
+	fmt.Printf("\nLatency:\n")
+	fmt.Printf("  min:  %s\n", latencies[0])
+	fmt.Printf("  mean: %s\n", total/time.Duration(requests))
+	fmt.Printf("  p50:  %s\n", server.Percentile(latencies, 50))
+	fmt.Printf("  p90:  %s\n", server.Percentile(latencies, 90))
+	fmt.Printf("  p95:  %s\n", server.Percentile(latencies, 95))
+	fmt.Printf("  p99:  %s\n", server.Percentile(latencies, 99))
+	fmt.Printf("  max:  %s\n", latencies[requests-1])
+}
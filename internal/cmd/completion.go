@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// completionCmd represents the completion command
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate shell completion scripts",
+	Long: `Generate a tab-completion script for zephyr commands and flags.
+
+To load completions:
+
+Bash:
+  $ source <(zephyr completion bash)
+  # To load for every session, on Linux:
+  $ zephyr completion bash > /etc/bash_completion.d/zephyr
+
+Zsh:
+  $ zephyr completion zsh > "${fpath[1]}/_zephyr"
+  # Completion must be enabled in ~/.zshrc with: autoload -U compinit; compinit
+
+Fish:
+  $ zephyr completion fish > ~/.config/fish/completions/zephyr.fish
+
+PowerShell:
+  PS> zephyr completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE:                  runCompletion,
+}
+
+func init() {
+	// Replace cobra's default completion command with this one
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.AddCommand(completionCmd)
+}
+
+func runCompletion(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+
+	switch args[0] {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(out, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(out)
+	case "fish":
+		return rootCmd.GenFishCompletion(out, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(out)
+	default:
+		return fmt.Errorf("unsupported shell: %s", args[0])
+	}
+}
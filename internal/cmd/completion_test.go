@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// runRoot executes the root command with args, returning its standard output
+func runRoot(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(io.Discard)
+	rootCmd.SetArgs(args)
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		rootCmd.SetArgs(nil)
+	})
+
+	err := rootCmd.Execute()
+	return out.String(), err
+}
+
+func TestCompletion(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		t.Run(shell, func(t *testing.T) {
+			out, err := runRoot(t, "completion", shell)
+			if err != nil {
+				t.Fatalf("completion %s: %v", shell, err)
+			}
+			if out == "" {
+				t.Errorf("completion %s produced no output", shell)
+			}
+		})
+	}
+}
+
+func TestCompletionRejectsUnknownShell(t *testing.T) {
+	if _, err := runRoot(t, "completion", "tcsh"); err == nil {
+		t.Error("completion tcsh succeeded, want an error")
+	}
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eadydb/zephyr/pkg/plugin"
+	"github.com/spf13/cobra"
+)
+
+// defaultPluginsDir is where the server looks for plugins
+const defaultPluginsDir = "./plugins"
+
+// pluginCmd represents the plugin command
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Plugin inspection and authoring commands",
+	Long:  `Commands for inspecting and scaffolding Zephyr plugins without running the server.`,
+}
+
+// pluginInfoCmd represents the plugin info subcommand
+var pluginInfoCmd = &cobra.Command{
+	Use:   "info <name>",
+	Short: "Show detailed information about a plugin",
+	Long: `Show the metadata of a single plugin from its plugin.json and, if the
+compiled .so is present, the input schema of the tool it provides.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPluginInfo,
+}
+
+func init() {
+	rootCmd.AddCommand(pluginCmd)
+	pluginCmd.AddCommand(pluginInfoCmd)
+
+	// Plugin-specific flags
+	pluginCmd.PersistentFlags().String("plugins-dir", defaultPluginsDir, "directory containing plugins")
+	pluginInfoCmd.Flags().Bool("json", false, "print the information as JSON")
+}
+
+// pluginInfo is the detailed description of a single plugin
+type pluginInfo struct {
+	Name           string                 `json:"name"`
+	Version        string                 `json:"version"`
+	Description    string                 `json:"description"`
+	Author         string                 `json:"author"`
+	APIVersion     string                 `json:"api_version"`
+	Dependencies   []string               `json:"dependencies"`
+	Permissions    []string               `json:"permissions"`
+	Directory      string                 `json:"directory"`
+	EnabledDefault bool                   `json:"enabled_default"`
+	Compiled       bool                   `json:"compiled"`
+	InputSchema    map[string]interface{} `json:"input_schema,omitempty"`
+}
+
+func runPluginInfo(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	pluginsDir, _ := cmd.Flags().GetString("plugins-dir")
+
+	manager := plugin.NewPluginManager(pluginsDir, nil)
+	if err := manager.DiscoverPlugins(); err != nil {
+		return fmt.Errorf("failed to discover plugins: %w", err)
+	}
+
+	status, exists := manager.ListPlugins()[name]
+	if !exists {
+		return fmt.Errorf("plugin %s not found in %s", name, pluginsDir)
+	}
+	metadata := manager.DiscoveredPlugins()[name]
+
+	info := pluginInfo{
+		Name:           metadata.Name,
+		Version:        metadata.Version,
+		Description:    metadata.Description,
+		Author:         metadata.Author,
+		APIVersion:     metadata.APIVersion,
+		Dependencies:   metadata.Dependencies,
+		Permissions:    metadata.Permissions,
+		Directory:      status.Directory,
+		EnabledDefault: metadata.EnabledDefault,
+	}
+
+	// The input schema is only available from the compiled plugin
+	if _, err := os.Stat(filepath.Join(status.Directory, name+".so")); err == nil {
+		if err := manager.LoadPlugin(name); err != nil {
+			return fmt.Errorf("failed to load plugin %s: %w", name, err)
+		}
+		if loaded, ok := manager.GetPlugin(name); ok {
+			info.Compiled = true
+			info.InputSchema = loaded.Plugin.InputSchema()
+		}
+		if err := manager.UnloadPlugin(name); err != nil {
+			return fmt.Errorf("failed to unload plugin %s: %w", name, err)
+		}
+	}
+
+	asJSON, _ := cmd.Flags().GetBool("json")
+	if asJSON {
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal plugin info: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printPluginInfo(info)
+	return nil
+}
+
+// printPluginInfo prints the plugin information in a human-readable layout
+func printPluginInfo(info pluginInfo) {
+	fmt.Printf("Name:         %s\n", info.Name)
+	fmt.Printf("Version:      %s\n", info.Version)
+	fmt.Printf("Description:  %s\n", info.Description)
+	fmt.Printf("Author:       %s\n", info.Author)
+	fmt.Printf("API Version:  %s\n", info.APIVersion)
+	fmt.Printf("Dependencies: %s\n", joinOrNone(info.Dependencies))
+	fmt.Printf("Permissions:  %s\n", joinOrNone(info.Permissions))
+	fmt.Printf("Directory:    %s\n", info.Directory)
+	fmt.Printf("Opt-in:       %t\n", !info.EnabledDefault)
+
+	if !info.Compiled {
+		fmt.Printf("\nInput schema unavailable: %s.so has not been built\n", info.Name)
+		return
+	}
+
+	schema, err := json.MarshalIndent(info.InputSchema, "", "  ")
+	if err != nil {
+		fmt.Printf("\nInput schema unavailable: %v\n", err)
+		return
+	}
+	fmt.Printf("\nInput schema:\n%s\n", schema)
+}
+
+// joinOrNone joins values with commas, or returns "none" for an empty list
+func joinOrNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
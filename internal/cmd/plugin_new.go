@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+// pluginNewCmd represents the plugin new subcommand
+var pluginNewCmd = &cobra.Command{
+	Use:   "new <name>",
+	Short: "Scaffold a new plugin",
+	Long: `Create a plugin directory containing a main.go that implements the
+DynamicPlugin interface, a plugin.json with the required metadata, and a
+Makefile that builds the .so. The generated plugin builds as-is and echoes
+its input, ready to be filled in.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPluginNew,
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginNewCmd)
+
+	pluginNewCmd.Flags().String("author", "", "plugin author recorded in plugin.json")
+	pluginNewCmd.Flags().String("description", "", "plugin description (default \"<name> tool\")")
+}
+
+// pluginNamePattern restricts plugin names to valid tool names and package paths
+var pluginNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// scaffoldData fills the plugin templates
+type scaffoldData struct {
+	Name        string
+	TypeName    string
+	Description string
+	Author      string
+}
+
+// scaffoldFuncs quote user-supplied text for the language of each template
+var scaffoldFuncs = template.FuncMap{
+	"json": func(s string) (string, error) {
+		data, err := json.Marshal(s)
+		return string(data), err
+	},
+}
+
+// scaffoldFiles maps each generated file to its template
+var scaffoldFiles = map[string]*template.Template{
+	"main.go":     template.Must(template.New("main.go").Funcs(scaffoldFuncs).Parse(mainTemplate)),
+	"plugin.json": template.Must(template.New("plugin.json").Funcs(scaffoldFuncs).Parse(metadataTemplate)),
+	"Makefile":    template.Must(template.New("Makefile").Funcs(scaffoldFuncs).Parse(makefileTemplate)),
+}
+
+func runPluginNew(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if !pluginNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid plugin name %q: use lowercase letters, digits, and underscores, starting with a letter", name)
+	}
+
+	pluginsDir, _ := cmd.Flags().GetString("plugins-dir")
+	author, _ := cmd.Flags().GetString("author")
+	description, _ := cmd.Flags().GetString("description")
+	if description == "" {
+		description = name + " tool"
+	}
+
+	dir := filepath.Join(pluginsDir, name)
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("plugin directory already exists: %s", dir)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create plugin directory: %w", err)
+	}
+
+	data := scaffoldData{
+		Name:        name,
+		TypeName:    pluginTypeName(name),
+		Description: description,
+		Author:      author,
+	}
+
+	for file, tmpl := range scaffoldFiles {
+		if err := writeScaffoldFile(filepath.Join(dir, file), tmpl, data); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Created plugin %s in %s\n", name, dir)
+	fmt.Printf("\nBuild it with:\n  make -C %s\n", dir)
+	return nil
+}
+
+// writeScaffoldFile renders a template into a new file
+func writeScaffoldFile(path string, tmpl *template.Template, data scaffoldData) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if err := tmpl.Execute(file, data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// pluginTypeName converts a plugin name such as "word_count" to "WordCountPlugin"
+func pluginTypeName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	b.WriteString("Plugin")
+	return b.String()
+}
+
+const mainTemplate = `package main
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/eadydb/zephyr/pkg/plugin"
+)
+
+// Plugin is the exported plugin instance
+var Plugin plugin.DynamicPlugin = &{{.TypeName}}{}
+
+// {{.TypeName}} implements the DynamicPlugin interface
+type {{.TypeName}} struct {
+	initialized atomic.Bool // read by Execute while a reload runs Initialize or Shutdown
+}
+
+// {{.TypeName}}Args are the bound arguments of a call
+type {{.TypeName}}Args struct {
+	Message string ` + "`" + `json:"message" validate:"required"` + "`" + `
+}
+
+// NewPlugin is the factory function that will be called by the plugin loader
+func NewPlugin() plugin.DynamicPlugin {
+	return &{{.TypeName}}{}
+}
+
+// Name returns the plugin name
+func (p *{{.TypeName}}) Name() string {
+	return "{{.Name}}"
+}
+
+// Version returns the plugin version
+func (p *{{.TypeName}}) Version() string {
+	return "0.1.0"
+}
+
+// Description returns the plugin description
+func (p *{{.TypeName}}) Description() string {
+	return {{printf "%q" .Description}}
+}
+
+// Initialize initializes the plugin
+func (p *{{.TypeName}}) Initialize() error {
+	if !p.initialized.CompareAndSwap(false, true) {
+		return fmt.Errorf("plugin already initialized")
+	}
+	return nil
+}
+
+// Shutdown cleans up the plugin
+func (p *{{.TypeName}}) Shutdown() error {
+	p.initialized.Store(false)
+	return nil
+}
+
+// MCPToolDefinition returns the MCP tool definition
+func (p *{{.TypeName}}) MCPToolDefinition() plugin.MCPTool {
+	return plugin.MCPTool{
+		Name:        "{{.Name}}",
+		Description: {{printf "%q" .Description}},
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"message": map[string]interface{}{
+					"type":        "string",
+					"description": "Message to echo back",
+				},
+			},
+			"required": []string{"message"},
+		},
+	}
+}
+
+// InputSchema returns the input schema for the tool
+func (p *{{.TypeName}}) InputSchema() map[string]interface{} {
+	return p.MCPToolDefinition().InputSchema
+}
+
+// Execute executes the tool with the given arguments
+func (p *{{.TypeName}}) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	if !p.initialized.Load() {
+		return nil, plugin.ErrNotInitialized
+	}
+
+	var opts {{.TypeName}}Args
+	if err := plugin.BindArgs(args, &opts); err != nil {
+		return nil, err
+	}
+
+	// TODO: implement the tool
+	return plugin.JSONResult(map[string]interface{}{
+		"message": opts.Message,
+	}), nil
+}
+
+// main function is required for plugin compilation but won't be used
+func main() {
+	// This is a plugin, main() won't be called
+}
+`
+
+const metadataTemplate = `{
+  "name": "{{.Name}}",
+  "version": "0.1.0",
+  "description": {{json .Description}},
+  "author": {{json .Author}},
+  "api_version": "1.0",
+  "entry_point": "{{.Name}}.so",
+  "dependencies": [],
+  "permissions": [],
+  "config_schema": {
+    "type": "object",
+    "properties": {}
+  }
+}
+`
+
+const makefileTemplate = `# {{.TypeName}} Makefile
+
+PLUGIN_NAME = {{.Name}}
+SO_FILE = $(PLUGIN_NAME).so
+MAIN_FILE = main.go
+
+# Go build flags for plugin
+GO_BUILD_FLAGS = -buildmode=plugin -ldflags="-s -w"
+
+# Default target
+all: build
+
+# Build the plugin
+build:
+	@echo "Building $(PLUGIN_NAME) plugin..."
+	go build $(GO_BUILD_FLAGS) -o $(SO_FILE) $(MAIN_FILE)
+	@echo "Plugin built successfully: $(SO_FILE)"
+
+# Clean build artifacts
+clean:
+	@echo "Cleaning $(PLUGIN_NAME) plugin..."
+	rm -f $(SO_FILE)
+	@echo "Clean complete"
+
+.PHONY: all build clean
+`
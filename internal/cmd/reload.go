@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/eadydb/zephyr/internal/config"
 	"github.com/spf13/cobra"
@@ -15,8 +17,8 @@ var reloadCmd = &cobra.Command{
 	Long: `Reload configuration commands for the running MCP server.
 
 Note: This command validates the configuration file but does not communicate
-with a running server instance. For runtime configuration reloading, the server
-must be started with the --hot-reload flag.`,
+with a running server instance. For runtime configuration reloading, start the
+server with the --hot-reload flag or send it SIGHUP (kill -HUP <pid>).`,
 }
 
 // configReloadCmd represents the config reload subcommand
@@ -41,6 +43,26 @@ func init() {
 
 	// Reload-specific flags
 	configReloadCmd.Flags().BoolP("verbose", "v", false, "show detailed configuration after reload test")
+	configReloadCmd.Flags().Bool("json", false, "print the result as JSON")
+}
+
+// reloadResult is the machine-readable outcome of a reload test
+type reloadResult struct {
+	Valid   bool           `json:"valid"`
+	Config  string         `json:"config"`
+	Error   string         `json:"error,omitempty"`
+	Summary *reloadSummary `json:"summary,omitempty"`
+}
+
+// reloadSummary mirrors the details shown by --verbose
+type reloadSummary struct {
+	Server         string `json:"server"`
+	Version        string `json:"version"`
+	Transport      string `json:"transport"`
+	Monitoring     bool   `json:"monitoring"`
+	MonitoringPort int    `json:"monitoring_port"`
+	PluginsEnabled int    `json:"plugins_enabled"`
+	Debug          bool   `json:"debug"`
 }
 
 func runConfigReload(cmd *cobra.Command, args []string) error {
@@ -49,6 +71,11 @@ func runConfigReload(cmd *cobra.Command, args []string) error {
 		configPath = "config.yaml"
 	}
 
+	asJSON, _ := cmd.Flags().GetBool("json")
+	if asJSON {
+		return runConfigReloadJSON(cmd, configPath)
+	}
+
 	fmt.Printf("Testing configuration reload from: %s\n", configPath)
 
 	// Test loading the configuration
@@ -65,7 +92,7 @@ func runConfigReload(cmd *cobra.Command, args []string) error {
 	if verbose {
 		fmt.Printf("\nConfiguration details:\n")
 		fmt.Printf("  Server: %s v%s\n", cfg.Server.Name, cfg.Server.Version)
-		fmt.Printf("  Transport: %s\n", cfg.Transport.Protocol)
+		fmt.Printf("  Transport: %s\n", strings.Join(cfg.Transport.EnabledProtocols(), ", "))
 		fmt.Printf("  Monitoring: %v (port %d)\n", cfg.Monitoring.Enabled, cfg.Monitoring.Port)
 		fmt.Printf("  Plugins enabled: %d\n", countEnabledPlugins(cfg))
 
@@ -78,11 +105,45 @@ func runConfigReload(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// countEnabledPlugins counts the number of enabled plugins in the configuration
+// runConfigReloadJSON tests the configuration and prints the outcome as JSON.
+// An invalid configuration still exits non-zero.
+func runConfigReloadJSON(cmd *cobra.Command, configPath string) error {
+	// Keep stdout parseable: the JSON already carries the error
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	result := reloadResult{Config: configPath}
+
+	cfg, loadErr := config.Load(configPath)
+	if loadErr != nil {
+		result.Error = loadErr.Error()
+	} else {
+		result.Valid = true
+		result.Summary = &reloadSummary{
+			Server:         cfg.Server.Name,
+			Version:        cfg.Server.Version,
+			Transport:      strings.Join(cfg.Transport.EnabledProtocols(), ","),
+			Monitoring:     cfg.Monitoring.Enabled,
+			MonitoringPort: cfg.Monitoring.Port,
+			PluginsEnabled: countEnabledPlugins(cfg),
+			Debug:          cfg.Server.Debug,
+		}
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal reload result: %w", err)
+	}
+	fmt.Println(string(data))
+
+	return loadErr
+}
+
+// countEnabledPlugins counts the plugins the configuration explicitly enables
 func countEnabledPlugins(cfg *config.Config) int {
 	count := 0
 	for _, tool := range cfg.Plugins.Tools {
-		if tool.Enabled {
+		if tool.Enabled != nil && *tool.Enabled {
 			count++
 		}
 	}
@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -12,6 +14,11 @@ var (
 	cfgFile   string
 	logLevel  string
 	logFormat string
+	quiet     bool
+
+	logFile    string
+	logMaxSize int
+	logMaxAge  time.Duration
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -47,6 +54,10 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is ./config.yaml)")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "log level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "log format (text, json)")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "suppress informational messages on stderr")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "write logs to this file instead of the configured output")
+	rootCmd.PersistentFlags().IntVar(&logMaxSize, "log-max-size", 0, "rotate the log file after this many megabytes (overrides logging.max_size)")
+	rootCmd.PersistentFlags().DurationVar(&logMaxAge, "log-max-age", 0, "remove rotated log files older than this (overrides logging.max_age)")
 
 	// Bind flags to viper
 	viper.BindPFlag("log.level", rootCmd.PersistentFlags().Lookup("log-level"))
@@ -55,6 +66,11 @@ func init() {
 
 // initConfig reads in config file and ENV variables if set
 func initConfig() {
+	// Commands that log through the default logger only report warnings and errors
+	if quiet {
+		slog.SetLogLoggerLevel(slog.LevelWarn)
+	}
+
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
@@ -71,8 +87,17 @@ func initConfig() {
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Fprintf(os.Stderr, "Using config file: %s\n", viper.ConfigFileUsed())
+		infof("Using config file: %s\n", viper.ConfigFileUsed())
+	}
+}
+
+// infof prints an informational message to stderr unless --quiet is set.
+// Errors should be written directly so they are never suppressed.
+func infof(format string, args ...interface{}) {
+	if quiet {
+		return
 	}
+	fmt.Fprintf(os.Stderr, format, args...)
 }
 
 // GetConfigFile returns the config file path
@@ -89,3 +114,8 @@ func GetLogLevel() string {
 func GetLogFormat() string {
 	return logFormat
 }
+
+// GetLogFile returns the log file path and rotation settings from the command line
+func GetLogFile() (path string, maxSize int, maxAge time.Duration) {
+	return logFile, logMaxSize, logMaxAge
+}
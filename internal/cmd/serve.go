@@ -38,11 +38,17 @@ func init() {
 	serveCmd.Flags().Int("port", 0, "port number for network transports")
 	serveCmd.Flags().Bool("monitoring", false, "enable monitoring endpoints")
 	serveCmd.Flags().Bool("hot-reload", false, "enable configuration hot reload")
+	serveCmd.Flags().String("workdir", "", "working directory to resolve relative paths against")
+	serveCmd.Flags().Bool("self-test", false, "probe each plugin with its declared self-test input after loading")
 }
 
 func runServe(cmd *cobra.Command, args []string) error {
 	// Check for hot reload flag
 	hotReload, _ := cmd.Flags().GetBool("hot-reload")
+	workDir, _ := cmd.Flags().GetString("workdir")
+	selfTest, _ := cmd.Flags().GetBool("self-test")
+
+	logFile, logMaxSize, logMaxAge := GetLogFile()
 
 	// Get CLI configuration
 	opts := &app.AppOptions{
@@ -50,6 +56,11 @@ func runServe(cmd *cobra.Command, args []string) error {
 		LogLevel:        GetLogLevel(),
 		LogFormat:       GetLogFormat(),
 		EnableHotReload: hotReload,
+		WorkDir:         workDir,
+		SelfTest:        selfTest,
+		LogFile:         logFile,
+		LogMaxSize:      logMaxSize,
+		LogMaxAge:       logMaxAge,
 	}
 
 	// Create and initialize application
@@ -74,6 +85,7 @@ func applyServeFlags(cmd *cobra.Command, app *app.App) error {
 	if cmd.Flags().Changed("transport") {
 		transport, _ := cmd.Flags().GetString("transport")
 		config.Transport.Protocol = transport
+		config.Transport.Protocols = nil
 	}
 
 	// Apply host override
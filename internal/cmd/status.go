@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/eadydb/zephyr/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Check whether a running server's transports are healthy",
+	Long: `Probe the health endpoint of each configured network transport (SSE or
+HTTP) and report whether it is up, along with the response latency. When
+several transports are configured, the JSON output is an array of results.
+
+The STDIO transport is attached to the process that launched the server and
+cannot be probed remotely.`,
+	RunE: runStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	// Status-specific flags
+	statusCmd.Flags().Bool("json", false, "print the result as JSON")
+	statusCmd.Flags().Duration("timeout", 5*time.Second, "how long to wait for the health endpoint")
+}
+
+// statusResult is the outcome of a transport health probe
+type statusResult struct {
+	Transport  string  `json:"transport"`
+	Status     string  `json:"status"` // up, down, or unknown
+	URL        string  `json:"url,omitempty"`
+	HTTPStatus int     `json:"http_status,omitempty"`
+	LatencyMs  float64 `json:"latency_ms,omitempty"`
+	Message    string  `json:"message,omitempty"`
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(GetConfigFile())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	asJSON, _ := cmd.Flags().GetBool("json")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	protocols := cfg.Transport.EnabledProtocols()
+	results := make([]statusResult, 0, len(protocols))
+	for _, protocol := range protocols {
+		results = append(results, probeTransport(cfg, protocol, timeout))
+	}
+
+	if asJSON {
+		// A single transport keeps the original object output
+		var payload interface{} = results
+		if len(results) == 1 {
+			payload = results[0]
+		}
+		data, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal status: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		for _, result := range results {
+			printStatus(result)
+		}
+	}
+
+	for _, result := range results {
+		if result.Status == "down" {
+			// The result already explains the failure
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			return fmt.Errorf("transport %s is down", result.Transport)
+		}
+	}
+	return nil
+}
+
+// probeTransport checks the health endpoint of one configured transport
+func probeTransport(cfg *config.Config, protocol string, timeout time.Duration) statusResult {
+	result := statusResult{Transport: protocol, Status: "unknown"}
+
+	var host, healthPath string
+	var port int
+	switch protocol {
+	case "sse":
+		host, port, healthPath = cfg.Transport.SSE.Host, cfg.Transport.SSE.Port, cfg.Transport.SSE.HealthPath
+	case "http":
+		host, port, healthPath = cfg.Transport.HTTP.Host, cfg.Transport.HTTP.Port, cfg.Transport.HTTP.HealthPath
+	case "stdio":
+		result.Message = "the stdio transport is bound to the launching process and cannot be probed remotely"
+		return result
+	default:
+		result.Message = fmt.Sprintf("transport %s has no known health endpoint", protocol)
+		return result
+	}
+
+	// A wildcard bind address is reachable on loopback
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	result.URL = fmt.Sprintf("http://%s%s", net.JoinHostPort(host, strconv.Itoa(port)), healthPath)
+
+	client := &http.Client{Timeout: timeout}
+	start := time.Now()
+	resp, err := client.Get(result.URL)
+	result.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	if err != nil {
+		result.Status = "down"
+		result.Message = err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+
+	result.HTTPStatus = resp.StatusCode
+	if resp.StatusCode == http.StatusOK {
+		result.Status = "up"
+	} else {
+		result.Status = "down"
+		result.Message = fmt.Sprintf("health endpoint returned %s", resp.Status)
+	}
+	return result
+}
+
+// printStatus prints a probe result for humans
+func printStatus(result statusResult) {
+	switch result.Status {
+	case "up":
+		fmt.Printf("✅ %s transport is up (%s, %.2fms)\n", result.Transport, result.URL, result.LatencyMs)
+	case "down":
+		fmt.Fprintf(os.Stderr, "❌ %s transport is down (%s): %s\n", result.Transport, result.URL, result.Message)
+	default:
+		fmt.Printf("ℹ️  %s transport: %s\n", result.Transport, result.Message)
+	}
+}
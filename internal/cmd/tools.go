@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+
+	"github.com/eadydb/zephyr/internal/config"
+	"github.com/eadydb/zephyr/internal/registry"
+	"github.com/eadydb/zephyr/pkg/plugin"
+	"github.com/spf13/cobra"
+)
+
+// toolsCmd represents the tools command
+var toolsCmd = &cobra.Command{
+	Use:   "tools",
+	Short: "Tool definition commands",
+	Long:  `Commands for working with the MCP tools provided by Zephyr plugins.`,
+}
+
+// toolsExportCmd represents the tools export subcommand
+var toolsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the JSON schemas of all tools",
+	Long: `Discover and load all plugins, then print a JSON array of
+{name, description, inputSchema} for every registered tool. This matches the
+tools/list payload a client would receive, without starting a transport.`,
+	RunE: runToolsExport,
+}
+
+func init() {
+	rootCmd.AddCommand(toolsCmd)
+	toolsCmd.AddCommand(toolsExportCmd)
+
+	// Tools-specific flags
+	toolsExportCmd.Flags().String("plugins-dir", defaultPluginsDir, "directory containing plugins")
+	toolsExportCmd.Flags().StringP("output", "o", "", "write the schemas to a file instead of stdout")
+}
+
+func runToolsExport(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(GetConfigFile())
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	pluginsDir, _ := cmd.Flags().GetString("plugins-dir")
+
+	toolRegistry := registry.NewRegistry(&cfg.Plugins)
+	defer toolRegistry.Shutdown()
+
+	manager := plugin.NewPluginManager(pluginsDir, toolRegistry)
+	if err := manager.DiscoverPlugins(); err != nil {
+		return fmt.Errorf("failed to discover plugins: %w", err)
+	}
+	if err := manager.LoadAllPlugins(); err != nil {
+		slog.Warn("Some plugins failed to load", "error", err)
+	}
+
+	tools := toolRegistry.ListTools()
+	definitions := make([]plugin.MCPTool, 0, len(tools))
+	for _, tool := range tools {
+		definitions = append(definitions, plugin.MCPTool{
+			Name:        tool.Name(),
+			Description: tool.Description(),
+			InputSchema: tool.InputSchema(),
+		})
+	}
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Name < definitions[j].Name
+	})
+
+	data, err := json.MarshalIndent(definitions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tool definitions: %w", err)
+	}
+	data = append(data, '\n')
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+
+	if err := os.WriteFile(output, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write tool definitions: %w", err)
+	}
+	infof("Exported %d tool definitions to %s\n", len(definitions), output)
+	return nil
+}
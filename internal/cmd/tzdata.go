@@ -0,0 +1,10 @@
+//go:build tzdata
+
+package cmd
+
+// Building with -tags tzdata embeds the IANA time zone database (about 450KB)
+// so timezone lookups work on hosts without zoneinfo, such as minimal container
+// images. Plugins share the host's time package and resolve zones from the same
+// copy. The embedded data is only as current as the Go release that built the
+// binary; without the tag the host's zoneinfo is used and follows system updates.
+import _ "time/tzdata"
@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 	"runtime"
+	"sort"
 
+	"github.com/eadydb/zephyr/pkg/plugin"
 	"github.com/spf13/cobra"
 )
 
@@ -20,6 +22,7 @@ func init() {
 
 	// Version-specific flags
 	versionCmd.Flags().BoolP("short", "s", false, "print only the version number")
+	versionCmd.Flags().BoolP("modules", "m", false, "list the module versions plugins must be built against")
 }
 
 func runVersion(cmd *cobra.Command, args []string) {
@@ -35,4 +38,21 @@ func runVersion(cmd *cobra.Command, args []string) {
 	fmt.Printf("Go Version: %s\n", runtime.Version())
 	fmt.Printf("Platform:   %s/%s\n", runtime.GOOS, runtime.GOARCH)
 	fmt.Printf("Compiler:   %s\n", runtime.Compiler)
+
+	modules, _ := cmd.Flags().GetBool("modules")
+	if !modules {
+		return
+	}
+
+	manifest := plugin.HostBuildManifest()
+	paths := make([]string, 0, len(manifest.Modules))
+	for path := range manifest.Modules {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	fmt.Printf("\nModules:\n")
+	for _, path := range paths {
+		fmt.Printf("  %s %s\n", path, manifest.Modules[path])
+	}
 }
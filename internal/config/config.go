@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/eadydb/zephyr/pkg/redact"
 	"gopkg.in/yaml.v3"
 )
 
@@ -22,17 +23,77 @@ type Config struct {
 
 // ServerConfig holds server-level configuration
 type ServerConfig struct {
-	Name    string `yaml:"name"`
-	Version string `yaml:"version"`
-	Debug   bool   `yaml:"debug"`
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Debug      bool   `yaml:"debug"`
+	PrettyJSON bool   `yaml:"pretty_json"` // indent JSON tool results by default
+	WorkDir    string `yaml:"workdir"`     // directory relative paths are resolved against
+
+	// Results larger than ResourceThreshold bytes are returned as an MCP resource
+	// reference that stays readable for ResourceTTL; 0 disables
+	ResourceThreshold int           `yaml:"resource_threshold"`
+	ResourceTTL       time.Duration `yaml:"resource_ttl"`
+
+	// CacheMaxEntries bounds the response cache of tools with a cache_ttl
+	CacheMaxEntries int `yaml:"cache_max_entries"`
+
+	// Middleware orders the built-in tool call middleware, outermost first;
+	// leaving one out disables it. Empty uses the default order.
+	Middleware []string `yaml:"middleware"`
+
+	// Capabilities enables MCP capabilities beyond tools
+	Capabilities CapabilitiesConfig `yaml:"capabilities"`
+
+	// Instructions tell clients how to use the server and its tools, sent when
+	// they initialize. InstructionsFile reads them from a file instead.
+	Instructions     string `yaml:"instructions"`
+	InstructionsFile string `yaml:"instructions_file"`
+
+	// ReloadStopOnError aborts a hot reload at the first reload callback that
+	// fails or panics instead of running the rest
+	ReloadStopOnError bool `yaml:"reload_stop_on_error"`
+}
+
+// LoadInstructions returns the configured instructions, reading InstructionsFile
+// when it is set. A relative file is resolved against the working directory.
+func (s ServerConfig) LoadInstructions() (string, error) {
+	if s.InstructionsFile == "" {
+		return s.Instructions, nil
+	}
+
+	data, err := os.ReadFile(s.InstructionsFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read instructions file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// CapabilitiesConfig selects the optional MCP capabilities the server advertises
+type CapabilitiesConfig struct {
+	Prompts bool `yaml:"prompts"`
+	Logging bool `yaml:"logging"` // let clients set the server's MCP log level
 }
 
 // TransportConfig holds transport protocol configuration
 type TransportConfig struct {
-	Protocol string      `yaml:"protocol"`
-	STDIO    STDIOConfig `yaml:"stdio"`
-	SSE      SSEConfig   `yaml:"sse"`
-	HTTP     HTTPConfig  `yaml:"http"`
+	Protocol string `yaml:"protocol"`
+
+	// Protocols runs every listed transport at once against the same server;
+	// when set it takes precedence over Protocol
+	Protocols []string `yaml:"protocols"`
+
+	STDIO STDIOConfig `yaml:"stdio"`
+	SSE   SSEConfig   `yaml:"sse"`
+	HTTP  HTTPConfig  `yaml:"http"`
+}
+
+// EnabledProtocols returns the transports to run, falling back to Protocol
+// when no list is configured
+func (t TransportConfig) EnabledProtocols() []string {
+	if len(t.Protocols) > 0 {
+		return t.Protocols
+	}
+	return []string{t.Protocol}
 }
 
 // STDIOConfig holds STDIO transport configuration
@@ -42,21 +103,47 @@ type STDIOConfig struct {
 
 // SSEConfig holds Server-Sent Events configuration
 type SSEConfig struct {
-	Port        int    `yaml:"port"`
-	Host        string `yaml:"host"`
-	CORSEnabled bool   `yaml:"cors_enabled"`
+	Port           int           `yaml:"port"`
+	Host           string        `yaml:"host"`
+	CORSEnabled    bool          `yaml:"cors_enabled"`
+	IdleTimeout    time.Duration `yaml:"idle_timeout"`
+	MaxConnections int           `yaml:"max_connections"`
+
+	SSEEndpoint     string `yaml:"sse_endpoint"`
+	MessageEndpoint string `yaml:"message_endpoint"`
+	HealthPath      string `yaml:"health_path"`
+
+	// KeepAliveInterval is how often idle event streams are pinged so proxies keep them open
+	KeepAliveInterval time.Duration `yaml:"keep_alive_interval"`
+
+	// Read and write timeouts default to 0 (none): a finite write timeout would
+	// cut off long-lived event streams, and an expiring read deadline cancels them
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
 }
 
 // HTTPConfig holds HTTP transport configuration
 type HTTPConfig struct {
-	Port    int           `yaml:"port"`
-	Host    string        `yaml:"host"`
-	Timeout time.Duration `yaml:"timeout"`
+	Port           int           `yaml:"port"`
+	Host           string        `yaml:"host"`
+	Timeout        time.Duration `yaml:"timeout"`
+	IdleTimeout    time.Duration `yaml:"idle_timeout"`
+	MaxConnections int           `yaml:"max_connections"`
+	EndpointPath   string        `yaml:"endpoint_path"`
+	HealthPath     string        `yaml:"health_path"`
+	ReadyPath      string        `yaml:"ready_path"` // readiness probe, failing until tools are registered
+
+	// Read and write timeouts fall back to Timeout when unset
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
 }
 
 // PluginsConfig holds plugin system configuration
 type PluginsConfig struct {
 	Discovery DiscoveryConfig       `yaml:"discovery"`
+	Loading   LoadingConfig         `yaml:"loading"`
 	Tools     map[string]ToolConfig `yaml:"tools"`
 }
 
@@ -65,26 +152,128 @@ type DiscoveryConfig struct {
 	Enabled      bool          `yaml:"enabled"`
 	Directories  []string      `yaml:"directories"`
 	ScanInterval time.Duration `yaml:"scan_interval"`
+	ScanJitter   float64       `yaml:"scan_jitter"` // randomize each scan interval by up to ± this fraction
+	Recursive    bool          `yaml:"recursive"`   // find plugin.json in nested directories
+	MaxDepth     int           `yaml:"max_depth"`   // deepest level searched when recursive
+	MaxPlugins   int           `yaml:"max_plugins"` // most plugins discovery accepts
+}
+
+// LoadingConfig holds plugin loading configuration
+type LoadingConfig struct {
+	OpenTimeout      time.Duration `yaml:"open_timeout"`
+	LifecycleTimeout time.Duration `yaml:"lifecycle_timeout"` // bounds plugin Initialize and Shutdown
+	Lazy             bool          `yaml:"lazy"`              // defer opening plugins until their tool is first called
+	Concurrency      int           `yaml:"concurrency"`       // plugins opened at once, within dependency order
+
+	// FailOnLoadError treats every enabled plugin as critical, so one that is
+	// not built or fails to load aborts startup instead of being skipped
+	FailOnLoadError bool `yaml:"fail_on_load_error"`
 }
 
 // ToolConfig holds individual tool configuration
 type ToolConfig struct {
-	Enabled  bool                   `yaml:"enabled"`
-	Settings map[string]interface{} `yaml:"settings,inline"`
+	// Enabled overrides the plugin's enabled_default metadata flag; when unset
+	// the plugin's own default applies
+	Enabled   *bool                  `yaml:"enabled"`
+	Critical  bool                   `yaml:"critical"`   // must load before the transport starts
+	RateLimit int                    `yaml:"rate_limit"` // requests per minute, overrides the global limit
+	Settings  map[string]interface{} `yaml:"settings,inline"`
+}
+
+// IsEnabled reports whether the tool should load: the configured Enabled value
+// when set, otherwise the plugin's enabled-by-default flag
+func (t ToolConfig) IsEnabled(enabledDefault bool) bool {
+	if t.Enabled != nil {
+		return *t.Enabled
+	}
+	return enabledDefault
+}
+
+// CacheTTL returns how long the tool's responses may be cached, read from the
+// cache_ttl setting. Zero means the tool is not cached.
+func (t ToolConfig) CacheTTL() (time.Duration, error) {
+	value, exists := t.Settings["cache_ttl"]
+	if !exists {
+		return 0, nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("cache_ttl must be a duration string, got %T", value)
+	}
+	ttl, err := time.ParseDuration(str)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cache_ttl: %w", err)
+	}
+	if ttl < 0 {
+		return 0, fmt.Errorf("cache_ttl must not be negative")
+	}
+	return ttl, nil
+}
+
+// StructuredOutput reports whether the tool's JSON results are also returned
+// as parsed values, read from the structured_output setting
+func (t ToolConfig) StructuredOutput() (bool, error) {
+	value, exists := t.Settings["structured_output"]
+	if !exists {
+		return false, nil
+	}
+
+	enabled, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("structured_output must be a boolean, got %T", value)
+	}
+	return enabled, nil
 }
 
 // LoggingConfig holds logging configuration
 type LoggingConfig struct {
 	Level  string `yaml:"level"`
 	Format string `yaml:"format"`
-	Output string `yaml:"output"`
+	Output string `yaml:"output"` // stdout, stderr, or file
 	File   string `yaml:"file"`
+
+	// The log file is rotated once it reaches MaxSize megabytes and rotated
+	// files are removed after MaxAge; 0 disables either
+	MaxSize int           `yaml:"max_size"`
+	MaxAge  time.Duration `yaml:"max_age"`
+
+	// JSONStyle names the fields of JSON logs (--log-format json): slog keeps time, level and msg;
+	// ecs follows the Elastic Common Schema (@timestamp, log.level, message).
+	// JSONKeys then renames individual built-in keys (time, level, msg, source).
+	JSONStyle string            `yaml:"json_style"`
+	JSONKeys  map[string]string `yaml:"json_keys"`
 }
 
 // SecurityConfig holds security-related configuration
 type SecurityConfig struct {
 	RateLimit RateLimitConfig `yaml:"rate_limit"`
 	Timeout   TimeoutConfig   `yaml:"timeout"`
+	Audit     AuditConfig     `yaml:"audit"`
+	Memory    MemoryConfig    `yaml:"memory"`
+
+	// RedactPatterns are case-insensitive regular expressions; tool arguments
+	// whose keys match are masked wherever they are logged or recorded. A
+	// pattern matches anywhere in the key, so "token" also masks max_tokens;
+	// the default "token($|[^s])" leaves such counts out.
+	RedactPatterns []string `yaml:"redact_patterns"`
+
+	// TrustedProxies lists the CIDRs or addresses of reverse proxies whose
+	// X-Forwarded-For and X-Real-IP headers identify the real client
+	TrustedProxies []string `yaml:"trusted_proxies"`
+}
+
+// AuditConfig holds tool-call audit trail configuration
+type AuditConfig struct {
+	Sink string `yaml:"sink"` // none, slog, or file
+	File string `yaml:"file"` // destination for the file sink
+}
+
+// MemoryConfig holds the soft per-call allocation budget. The budget is measured
+// from process-wide allocation counters, so concurrent calls can inflate it.
+type MemoryConfig struct {
+	MaxCallBytes int64 `yaml:"max_call_bytes"` // 0 disables the check
+	Reject       bool  `yaml:"reject"`         // fail calls over budget instead of only logging
 }
 
 // RateLimitConfig holds rate limiting configuration
@@ -101,11 +290,46 @@ type TimeoutConfig struct {
 
 // MonitoringConfig configures monitoring and metrics
 type MonitoringConfig struct {
-	Enabled        bool            `yaml:"enabled"`
-	Port           int             `yaml:"port"`
-	Host           string          `yaml:"host"`
-	Endpoints      EndpointsConfig `yaml:"endpoints"`
-	UpdateInterval string          `yaml:"update_interval"`
+	Enabled          bool            `yaml:"enabled"`
+	Port             int             `yaml:"port"`
+	Host             string          `yaml:"host"`
+	Endpoints        EndpointsConfig `yaml:"endpoints"`
+	UpdateInterval   string          `yaml:"update_interval"`
+	HistogramBuckets []time.Duration `yaml:"histogram_buckets"`
+	ShutdownTimeout  time.Duration   `yaml:"shutdown_timeout"`
+	Snapshot         SnapshotConfig  `yaml:"snapshot"`
+
+	// PProfEnabled serves the Go profiling endpoints under /debug/pprof/. The
+	// monitoring server has no authentication, so keep it on a private host.
+	PProfEnabled bool `yaml:"pprof_enabled"`
+
+	// MaxGoroutines flips the health check to degraded while more goroutines
+	// are running, an early sign of a leak; 0 disables
+	MaxGoroutines int `yaml:"max_goroutines"`
+
+	// HealthHistorySize is how many health status transitions are kept for
+	// the <health>/history endpoint and /health?history=true
+	HealthHistorySize int `yaml:"health_history_size"`
+
+	// CORS for browser dashboards, disabled by default. An empty origin list
+	// allows any origin once enabled.
+	CORSEnabled bool     `yaml:"cors_enabled"`
+	CORSOrigins []string `yaml:"cors_origins"`
+
+	// MemStatsTTL is how long a runtime memory statistics snapshot is reused;
+	// reading them stops the world. 0 reads fresh statistics every time.
+	MemStatsTTL time.Duration `yaml:"memstats_ttl"`
+
+	// DrainLast keeps the monitoring server up, reporting draining, until every
+	// other component has shut down instead of stopping it first
+	DrainLast bool `yaml:"drain_last"`
+}
+
+// SnapshotConfig configures persisting metrics across restarts
+type SnapshotConfig struct {
+	Enabled bool   `yaml:"enabled"` // write a snapshot on graceful shutdown
+	File    string `yaml:"file"`
+	Restore bool   `yaml:"restore"` // merge the previous snapshot's counters on startup
 }
 
 // EndpointsConfig configures monitoring endpoints
@@ -144,6 +368,8 @@ func defaultConfig() *Config {
 			Name:    "zephyr-mcp-server",
 			Version: "1.0.0",
 			Debug:   false,
+
+			CacheMaxEntries: 1000,
 		},
 		Transport: TransportConfig{
 			Protocol: "stdio",
@@ -151,14 +377,27 @@ func defaultConfig() *Config {
 				BufferSize: 4096,
 			},
 			SSE: SSEConfig{
-				Port:        26841,
-				Host:        "localhost",
-				CORSEnabled: true,
+				Port:            26841,
+				Host:            "localhost",
+				CORSEnabled:     true,
+				IdleTimeout:     60 * time.Second,
+				SSEEndpoint:     "/sse",
+				MessageEndpoint: "/message",
+				HealthPath:      "/health",
+
+				KeepAliveInterval: 10 * time.Second,
+				ReadHeaderTimeout: 10 * time.Second,
 			},
 			HTTP: HTTPConfig{
-				Port:    26842,
-				Host:    "localhost",
-				Timeout: 30 * time.Second,
+				Port:         26842,
+				Host:         "localhost",
+				Timeout:      30 * time.Second,
+				IdleTimeout:  60 * time.Second,
+				EndpointPath: "/mcp",
+				HealthPath:   "/health",
+				ReadyPath:    "/ready",
+
+				ReadHeaderTimeout: 10 * time.Second,
 			},
 		},
 		Plugins: PluginsConfig{
@@ -166,11 +405,17 @@ func defaultConfig() *Config {
 				Enabled:      true,
 				Directories:  []string{"./plugins"},
 				ScanInterval: 60 * time.Second,
+				MaxDepth:     5,
+				MaxPlugins:   256,
+			},
+			Loading: LoadingConfig{
+				OpenTimeout:      30 * time.Second,
+				LifecycleTimeout: 10 * time.Second,
+				Concurrency:      4,
 			},
 			Tools: map[string]ToolConfig{
-				"systeminfo": {Enabled: true},
+				"systeminfo": {},
 				"currenttime": {
-					Enabled: true,
 					Settings: map[string]interface{}{
 						"timezone": "UTC",
 					},
@@ -178,26 +423,34 @@ func defaultConfig() *Config {
 			},
 		},
 		Logging: LoggingConfig{
-			Level:  "info",
-			Format: "json",
-			Output: "stdout",
+			Level:     "info",
+			Format:    "json",
+			Output:    "stdout",
+			JSONStyle: "slog",
 		},
 		Security: SecurityConfig{
 			RateLimit: RateLimitConfig{
-				Enabled:           true,
+				Enabled:           false,
 				RequestsPerMinute: 100,
 			},
 			Timeout: TimeoutConfig{
 				Request:  10 * time.Second,
 				Shutdown: 30 * time.Second,
 			},
+			Audit: AuditConfig{
+				Sink: "none",
+			},
+			RedactPatterns: append([]string(nil), redact.DefaultPatterns...),
 		},
 		Monitoring: MonitoringConfig{
-			Enabled:        true,
-			Port:           26843,
-			Host:           "localhost",
-			Endpoints:      EndpointsConfig{Metrics: "/metrics", Health: "/health"},
-			UpdateInterval: "1m",
+			Enabled:           true,
+			Port:              26843,
+			Host:              "localhost",
+			Endpoints:         EndpointsConfig{Metrics: "/metrics", Health: "/health"},
+			UpdateInterval:    "1m",
+			ShutdownTimeout:   5 * time.Second,
+			MemStatsTTL:       time.Second,
+			HealthHistorySize: 50,
 		},
 	}
 }
@@ -230,6 +483,7 @@ func applyEnvOverrides(config *Config) {
 	// Transport configuration
 	if val := os.Getenv("ZEPHYR_TRANSPORT_PROTOCOL"); val != "" {
 		config.Transport.Protocol = val
+		config.Transport.Protocols = nil
 	}
 	if val := os.Getenv("ZEPHYR_TRANSPORT_SSE_PORT"); val != "" {
 		if port := parseIntEnv(val); port > 0 {
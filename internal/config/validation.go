@@ -2,29 +2,203 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
-// validate performs configuration validation
-func validate(config *Config) error {
-	// Validate transport protocol
-	validProtocols := map[string]bool{
+var (
+	protocolsMu sync.RWMutex
+
+	// validProtocols holds the transport protocols accepted by validation
+	validProtocols = map[string]bool{
 		"stdio": true,
 		"sse":   true,
 		"http":  true,
 	}
+)
+
+// RegisterProtocol marks an additional transport protocol as valid
+func RegisterProtocol(name string) {
+	protocolsMu.Lock()
+	defer protocolsMu.Unlock()
+	validProtocols[name] = true
+}
+
+// isValidProtocol reports whether the protocol has been registered
+func isValidProtocol(name string) bool {
+	protocolsMu.RLock()
+	defer protocolsMu.RUnlock()
+	return validProtocols[name]
+}
+
+// protocolNames returns the registered protocol names in sorted order
+func protocolNames() []string {
+	protocolsMu.RLock()
+	defer protocolsMu.RUnlock()
+
+	names := make([]string, 0, len(validProtocols))
+	for name := range validProtocols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FieldError is a single validation failure for a configuration field
+type FieldError struct {
+	Field   string // dotted YAML path, e.g. transport.sse.port
+	Message string
+}
+
+// ValidationError collects every validation failure in a configuration so
+// they can all be fixed in one pass
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Error lists each failing field with its message
+func (e *ValidationError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Field + ": " + e.Errors[0].Message
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d errors:", len(e.Errors))
+	for _, fieldErr := range e.Errors {
+		fmt.Fprintf(&b, "\n  %s: %s", fieldErr.Field, fieldErr.Message)
+	}
+	return b.String()
+}
+
+// add records a failure for the field
+func (e *ValidationError) add(field, format string, args ...interface{}) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// validate performs configuration validation, returning a *ValidationError
+// listing every problem found
+func validate(config *Config) error {
+	errs := &ValidationError{}
+
+	// Validate transport protocols
+	protocolField := "transport.protocol"
+	if len(config.Transport.Protocols) > 0 {
+		protocolField = "transport.protocols"
+	}
+	seenProtocols := make(map[string]bool)
+	for _, protocol := range config.Transport.EnabledProtocols() {
+		if !isValidProtocol(protocol) {
+			errs.add(protocolField, "invalid transport protocol: %s (must be one of: %s)",
+				protocol, strings.Join(protocolNames(), ", "))
+			continue
+		}
+		if seenProtocols[protocol] {
+			errs.add(protocolField, "transport protocol listed more than once: %s", protocol)
+		}
+		seenProtocols[protocol] = true
+	}
 
-	if !validProtocols[config.Transport.Protocol] {
-		return fmt.Errorf("invalid transport protocol: %s (must be one of: stdio, sse, http)", config.Transport.Protocol)
+	if seenProtocols["sse"] && seenProtocols["http"] &&
+		config.Transport.SSE.Host == config.Transport.HTTP.Host && config.Transport.SSE.Port == config.Transport.HTTP.Port {
+		errs.add("transport.http.port", "SSE and HTTP transports cannot share an address: %s:%d",
+			config.Transport.SSE.Host, config.Transport.SSE.Port)
 	}
 
 	// Validate port numbers
 	if config.Transport.SSE.Port < 1 || config.Transport.SSE.Port > 65535 {
-		return fmt.Errorf("invalid SSE port: %d (must be 1-65535)", config.Transport.SSE.Port)
+		errs.add("transport.sse.port", "invalid SSE port: %d (must be 1-65535)", config.Transport.SSE.Port)
 	}
 
 	if config.Transport.HTTP.Port < 1 || config.Transport.HTTP.Port > 65535 {
-		return fmt.Errorf("invalid HTTP port: %d (must be 1-65535)", config.Transport.HTTP.Port)
+		errs.add("transport.http.port", "invalid HTTP port: %d (must be 1-65535)", config.Transport.HTTP.Port)
+	}
+
+	// Validate connection limits and timeouts
+	for field, timeout := range map[string]time.Duration{
+		"transport.sse.idle_timeout":         config.Transport.SSE.IdleTimeout,
+		"transport.sse.read_timeout":         config.Transport.SSE.ReadTimeout,
+		"transport.sse.write_timeout":        config.Transport.SSE.WriteTimeout,
+		"transport.sse.read_header_timeout":  config.Transport.SSE.ReadHeaderTimeout,
+		"transport.http.idle_timeout":        config.Transport.HTTP.IdleTimeout,
+		"transport.http.read_timeout":        config.Transport.HTTP.ReadTimeout,
+		"transport.http.write_timeout":       config.Transport.HTTP.WriteTimeout,
+		"transport.http.read_header_timeout": config.Transport.HTTP.ReadHeaderTimeout,
+	} {
+		if timeout < 0 {
+			errs.add(field, "timeout must not be negative")
+		}
+	}
+
+	if config.Transport.SSE.KeepAliveInterval <= 0 {
+		errs.add("transport.sse.keep_alive_interval", "SSE keep-alive interval must be positive")
+	}
+
+	if config.Transport.SSE.MaxConnections < 0 {
+		errs.add("transport.sse.max_connections", "max connections must not be negative")
+	}
+	if config.Transport.HTTP.MaxConnections < 0 {
+		errs.add("transport.http.max_connections", "max connections must not be negative")
+	}
+
+	// Validate endpoint paths
+	for field, path := range map[string]string{
+		"transport.sse.sse_endpoint":     config.Transport.SSE.SSEEndpoint,
+		"transport.sse.message_endpoint": config.Transport.SSE.MessageEndpoint,
+		"transport.sse.health_path":      config.Transport.SSE.HealthPath,
+		"transport.http.endpoint_path":   config.Transport.HTTP.EndpointPath,
+		"transport.http.health_path":     config.Transport.HTTP.HealthPath,
+		"transport.http.ready_path":      config.Transport.HTTP.ReadyPath,
+		"monitoring.endpoints.metrics":   config.Monitoring.Endpoints.Metrics,
+		"monitoring.endpoints.health":    config.Monitoring.Endpoints.Health,
+	} {
+		if !strings.HasPrefix(path, "/") {
+			errs.add(field, "invalid endpoint path: %q (must start with /)", path)
+		}
+	}
+
+	if config.Transport.SSE.SSEEndpoint == config.Transport.SSE.MessageEndpoint {
+		errs.add("transport.sse.message_endpoint", "SSE endpoint and message endpoint must differ: %s", config.Transport.SSE.SSEEndpoint)
+	}
+
+	sseHealth := config.Transport.SSE.HealthPath
+	if sseHealth == config.Transport.SSE.SSEEndpoint || sseHealth == config.Transport.SSE.MessageEndpoint {
+		errs.add("transport.sse.health_path", "SSE health path overlaps an MCP endpoint: %s", sseHealth)
+	}
+
+	if config.Transport.HTTP.HealthPath == config.Transport.HTTP.EndpointPath {
+		errs.add("transport.http.health_path", "HTTP health path overlaps the MCP endpoint: %s", config.Transport.HTTP.HealthPath)
+	}
+
+	httpReady := config.Transport.HTTP.ReadyPath
+	if httpReady == config.Transport.HTTP.EndpointPath || httpReady == config.Transport.HTTP.HealthPath {
+		errs.add("transport.http.ready_path", "HTTP ready path overlaps the MCP endpoint or health path: %s", httpReady)
+	}
+
+	if config.Monitoring.Endpoints.Metrics == config.Monitoring.Endpoints.Health {
+		errs.add("monitoring.endpoints.health", "monitoring metrics and health endpoints must differ: %s", config.Monitoring.Endpoints.Health)
+	}
+
+	for field, path := range map[string]string{
+		"monitoring.endpoints.metrics": config.Monitoring.Endpoints.Metrics,
+		"monitoring.endpoints.health":  config.Monitoring.Endpoints.Health,
+	} {
+		if path == "/plugins" || strings.HasPrefix(path, "/plugins/") {
+			errs.add(field, "monitoring endpoint %s overlaps the reserved /plugins routes", path)
+		}
+		if path == "/tools" {
+			errs.add(field, "monitoring endpoint %s overlaps the reserved /tools route", path)
+		}
+		if field == "monitoring.endpoints.metrics" && path == config.Monitoring.Endpoints.Health+"/history" {
+			errs.add(field, "monitoring endpoint %s overlaps the health history route", path)
+		}
+		if config.Monitoring.PProfEnabled && strings.HasPrefix(path, "/debug/pprof/") {
+			errs.add(field, "monitoring endpoint %s overlaps the /debug/pprof/ routes", path)
+		}
 	}
 
 	// Validate log level
@@ -36,19 +210,216 @@ func validate(config *Config) error {
 	}
 
 	if !validLogLevels[config.Logging.Level] {
-		return fmt.Errorf("invalid log level: %s (must be one of: debug, info, warn, error)", config.Logging.Level)
+		errs.add("logging.level", "invalid log level: %s (must be one of: debug, info, warn, error)", config.Logging.Level)
+	}
+
+	switch config.Logging.Output {
+	case "", "stdout", "stderr":
+	case "file":
+		if config.Logging.File == "" {
+			errs.add("logging.file", "log file is required when logging output is file")
+		}
+	default:
+		errs.add("logging.output", "invalid log output: %s (must be one of: stdout, stderr, file)", config.Logging.Output)
+	}
+
+	if config.Logging.MaxSize < 0 {
+		errs.add("logging.max_size", "log max size must not be negative")
+	}
+	if config.Logging.MaxAge < 0 {
+		errs.add("logging.max_age", "log max age must not be negative")
+	}
+
+	switch config.Logging.JSONStyle {
+	case "", "slog", "ecs":
+	default:
+		errs.add("logging.json_style", "invalid JSON log style: %s (must be one of: slog, ecs)", config.Logging.JSONStyle)
+	}
+	for key, name := range config.Logging.JSONKeys {
+		switch key {
+		case "time", "level", "msg", "source":
+		default:
+			errs.add("logging.json_keys."+key, "unknown log key: %s (must be one of: time, level, msg, source)", key)
+		}
+		if name == "" {
+			errs.add("logging.json_keys."+key, "log key name must not be empty")
+		}
 	}
 
 	// Validate timeouts are positive
 	if config.Security.Timeout.Request <= 0 {
-		return fmt.Errorf("request timeout must be positive")
+		errs.add("security.timeout.request", "request timeout must be positive")
 	}
 
 	if config.Security.Timeout.Shutdown <= 0 {
-		return fmt.Errorf("shutdown timeout must be positive")
+		errs.add("security.timeout.shutdown", "shutdown timeout must be positive")
+	}
+
+	// Validate audit sink
+	switch config.Security.Audit.Sink {
+	case "", "none", "slog":
+	case "file":
+		if config.Security.Audit.File == "" {
+			errs.add("security.audit.file", "audit file is required for the file audit sink")
+		}
+	default:
+		errs.add("security.audit.sink", "invalid audit sink: %s (must be one of: none, slog, file)", config.Security.Audit.Sink)
+	}
+
+	if config.Server.ResourceThreshold < 0 {
+		errs.add("server.resource_threshold", "result resource threshold must not be negative")
+	}
+	if config.Server.ResourceTTL < 0 {
+		errs.add("server.resource_ttl", "result resource TTL must not be negative")
+	}
+
+	if config.Server.Instructions != "" && config.Server.InstructionsFile != "" {
+		errs.add("server.instructions_file", "set either instructions or instructions_file, not both")
+	}
+
+	if config.Server.CacheMaxEntries < 0 {
+		errs.add("server.cache_max_entries", "response cache max entries must not be negative")
+	}
+
+	// Validate tool middleware names and that none repeats
+	validMiddleware := map[string]bool{
+		"metrics":       true,
+		"audit":         true,
+		"rate_limit":    true,
+		"cache":         true,
+		"memory_budget": true,
+	}
+	seenMiddleware := make(map[string]bool)
+	for i, name := range config.Server.Middleware {
+		field := fmt.Sprintf("server.middleware[%d]", i)
+		if !validMiddleware[name] {
+			errs.add(field, "invalid tool middleware: %s (must be one of: metrics, audit, rate_limit, cache, memory_budget)", name)
+		} else if seenMiddleware[name] {
+			errs.add(field, "tool middleware listed more than once: %s", name)
+		}
+		seenMiddleware[name] = true
+	}
+
+	// Validate rate limits
+	if config.Security.RateLimit.Enabled && config.Security.RateLimit.RequestsPerMinute <= 0 {
+		errs.add("security.rate_limit.requests_per_minute", "rate limit requests per minute must be positive when rate limiting is enabled")
+	}
+
+	for _, name := range sortedToolNames(config.Plugins.Tools) {
+		tool := config.Plugins.Tools[name]
+		if tool.RateLimit < 0 {
+			errs.add("plugins.tools."+name+".rate_limit", "rate limit must not be negative")
+		}
+		if _, err := tool.CacheTTL(); err != nil {
+			errs.add("plugins.tools."+name+".cache_ttl", "%v", err)
+		}
+		if _, err := tool.StructuredOutput(); err != nil {
+			errs.add("plugins.tools."+name+".structured_output", "%v", err)
+		}
+	}
+
+	if config.Security.Memory.MaxCallBytes < 0 {
+		errs.add("security.memory.max_call_bytes", "memory max call bytes must not be negative")
+	}
+
+	// Validate redaction patterns compile
+	for i, pattern := range config.Security.RedactPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			errs.add(fmt.Sprintf("security.redact_patterns[%d]", i), "invalid redaction pattern %q: %v", pattern, err)
+		}
+	}
+
+	// Validate trusted proxies are addresses or CIDRs
+	for i, proxy := range config.Security.TrustedProxies {
+		if net.ParseIP(proxy) != nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(proxy); err != nil {
+			errs.add(fmt.Sprintf("security.trusted_proxies[%d]", i), "invalid trusted proxy %q: must be an IP address or CIDR", proxy)
+		}
+	}
+
+	if config.Monitoring.ShutdownTimeout < 0 {
+		errs.add("monitoring.shutdown_timeout", "monitoring shutdown timeout must not be negative")
+	}
+
+	if config.Monitoring.MaxGoroutines < 0 {
+		errs.add("monitoring.max_goroutines", "monitoring max goroutines must not be negative")
+	}
+	if config.Monitoring.HealthHistorySize <= 0 {
+		errs.add("monitoring.health_history_size", "monitoring health history size must be positive")
 	}
 
-	return nil
+	if config.Monitoring.MemStatsTTL < 0 {
+		errs.add("monitoring.memstats_ttl", "memory stats TTL must not be negative")
+	}
+
+	if config.Monitoring.Snapshot.Enabled && config.Monitoring.Snapshot.File == "" {
+		errs.add("monitoring.snapshot.file", "metrics snapshot file is required when snapshots are enabled")
+	}
+
+	// Validate histogram buckets are positive and strictly increasing
+	for i, bucket := range config.Monitoring.HistogramBuckets {
+		field := fmt.Sprintf("monitoring.histogram_buckets[%d]", i)
+		if bucket <= 0 {
+			errs.add(field, "histogram bucket must be positive: %s", bucket)
+		} else if i > 0 && bucket <= config.Monitoring.HistogramBuckets[i-1] {
+			errs.add(field, "histogram buckets must be strictly increasing")
+		}
+	}
+
+	if config.Plugins.Discovery.Enabled && config.Plugins.Discovery.ScanInterval <= 0 {
+		errs.add("plugins.discovery.scan_interval", "plugin discovery scan interval must be positive when discovery is enabled")
+	}
+
+	if jitter := config.Plugins.Discovery.ScanJitter; jitter < 0 || jitter >= 1 {
+		errs.add("plugins.discovery.scan_jitter", "plugin discovery scan jitter must be a fraction from 0 up to 1, got %g", jitter)
+	}
+
+	if config.Plugins.Discovery.MaxDepth < 0 {
+		errs.add("plugins.discovery.max_depth", "plugin discovery max depth must not be negative")
+	}
+
+	if config.Plugins.Discovery.MaxPlugins < 0 {
+		errs.add("plugins.discovery.max_plugins", "plugin discovery max plugins must not be negative")
+	}
+
+	if config.Plugins.Loading.OpenTimeout < 0 {
+		errs.add("plugins.loading.open_timeout", "plugin open timeout must not be negative")
+	}
+
+	if config.Plugins.Loading.LifecycleTimeout < 0 {
+		errs.add("plugins.loading.lifecycle_timeout", "plugin lifecycle timeout must not be negative")
+	}
+
+	if config.Plugins.Loading.Concurrency < 0 {
+		errs.add("plugins.loading.concurrency", "plugin load concurrency must not be negative")
+	}
+
+	// Lazy plugins only open on first call, too late to abort startup
+	if config.Plugins.Loading.FailOnLoadError && config.Plugins.Loading.Lazy {
+		errs.add("plugins.loading.fail_on_load_error", "fail on load error cannot be combined with lazy loading")
+	}
+
+	if len(errs.Errors) == 0 {
+		return nil
+	}
+
+	// Report in a stable order regardless of map iteration above
+	sort.SliceStable(errs.Errors, func(i, j int) bool {
+		return errs.Errors[i].Field < errs.Errors[j].Field
+	})
+	return errs
+}
+
+// sortedToolNames returns the configured tool names in sorted order
+func sortedToolNames(tools map[string]ToolConfig) []string {
+	names := make([]string, 0, len(tools))
+	for name := range tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // Enhanced parseIntEnv with proper error handling
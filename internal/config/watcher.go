@@ -4,16 +4,28 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 	"path/filepath"
+	"runtime/debug"
 	"sync"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+	"github.com/google/uuid"
 )
 
 // ReloadCallback is called when configuration is reloaded
 type ReloadCallback func(*Config) error
 
+// ReloadTrigger records what started a configuration reload
+type ReloadTrigger string
+
+const (
+	ReloadTriggerFile   ReloadTrigger = "file"   // the config file changed on disk
+	ReloadTriggerManual ReloadTrigger = "manual" // ReloadNow was called
+	ReloadTriggerSignal ReloadTrigger = "signal" // the process received SIGHUP
+)
+
 // Watcher monitors configuration file changes and triggers reloads
 type Watcher struct {
 	configPath string
@@ -26,16 +38,23 @@ type Watcher struct {
 	config  *Config
 	running bool
 	stopCh  chan struct{}
+	missing bool // the config file was removed; its directory is watched instead
 
 	// Debouncing
 	debounceDelay time.Duration
 	lastReload    time.Time
+
+	stopOnCallbackError bool
 }
 
 // WatcherOptions holds configuration for the watcher
 type WatcherOptions struct {
 	DebounceDelay time.Duration
 	Logger        *slog.Logger
+
+	// StopOnCallbackError skips the remaining callbacks after one fails or
+	// panics and returns its error from the reload
+	StopOnCallbackError bool
 }
 
 // NewWatcher creates a new configuration file watcher
@@ -72,6 +91,8 @@ func NewWatcher(configPath string, opts *WatcherOptions) (*Watcher, error) {
 		config:        config,
 		stopCh:        make(chan struct{}),
 		debounceDelay: opts.DebounceDelay,
+
+		stopOnCallbackError: opts.StopOnCallbackError,
 	}
 
 	return w, nil
@@ -144,8 +165,15 @@ func (w *Watcher) Stop() error {
 
 // ReloadNow manually triggers a configuration reload
 func (w *Watcher) ReloadNow() error {
-	w.logger.Info("Manual configuration reload triggered")
-	return w.reloadConfig()
+	return w.Reload(ReloadTriggerManual)
+}
+
+// Reload triggers a configuration reload attributed to the given trigger. Every
+// log line of the attempt carries the same reload_id.
+func (w *Watcher) Reload(trigger ReloadTrigger) error {
+	reloadID := uuid.NewString()
+	w.logger.Info("Configuration reload triggered", "reload_id", reloadID, "trigger", trigger)
+	return w.reloadConfig(reloadID, trigger)
 }
 
 // watchLoop is the main event loop for file watching
@@ -183,8 +211,9 @@ func (w *Watcher) watchLoop(ctx context.Context) {
 func (w *Watcher) handleFileEvent(event fsnotify.Event) {
 	w.logger.Debug("File system event", "event", event.String())
 
-	// Only handle write and create events
-	if event.Op&fsnotify.Write != fsnotify.Write && event.Op&fsnotify.Create != fsnotify.Create {
+	// Only handle writes and the file being created, removed, or renamed away
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) &&
+		!event.Has(fsnotify.Remove) && !event.Has(fsnotify.Rename) {
 		return
 	}
 
@@ -205,52 +234,140 @@ func (w *Watcher) handleFileEvent(event fsnotify.Event) {
 		return
 	}
 
+	if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		w.handleConfigRemoved(configPath)
+		return
+	}
+
+	w.mu.RLock()
+	missing := w.missing
+	w.mu.RUnlock()
+	if missing {
+		w.handleConfigRestored(configPath)
+		return
+	}
+
+	reloadID := uuid.NewString()
+	w.logger.Info("Configuration file changed",
+		"reload_id", reloadID,
+		"trigger", ReloadTriggerFile,
+		"event", event.Op.String())
+
 	// Debounce rapid file changes
 	w.mu.RLock()
 	if time.Since(w.lastReload) < w.debounceDelay {
-		w.logger.Debug("Skipping config reload due to debouncing")
+		w.logger.Debug("Skipping config reload due to debouncing", "reload_id", reloadID)
 		w.mu.RUnlock()
 		return
 	}
 	w.mu.RUnlock()
 
 	// Trigger reload
-	if err := w.reloadConfig(); err != nil {
-		w.logger.Error("Failed to reload configuration", "error", err)
+	if err := w.reloadConfig(reloadID, ReloadTriggerFile); err != nil {
+		w.logger.Error("Failed to reload configuration", "reload_id", reloadID, "error", err)
+	}
+}
+
+// handleConfigRemoved keeps the last loaded configuration when the config file
+// is deleted or renamed away, and watches its directory for the file to return
+func (w *Watcher) handleConfigRemoved(configPath string) {
+	w.mu.Lock()
+	if w.missing {
+		w.mu.Unlock()
+		return
+	}
+	w.missing = true
+	w.mu.Unlock()
+
+	w.logger.Warn("Configuration file removed, keeping last loaded configuration", "file", configPath)
+
+	// A renamed file keeps its watch, so drop it explicitly
+	w.fsWatcher.Remove(configPath)
+
+	dir := filepath.Dir(configPath)
+	if err := w.fsWatcher.Add(dir); err != nil {
+		w.logger.Error("Failed to watch configuration directory, file changes will not be detected",
+			"dir", dir, "error", err)
+		return
+	}
+
+	// Editors and deploys often replace the file with a rename, so it may already be back
+	if _, err := os.Stat(configPath); err == nil {
+		w.handleConfigRestored(configPath)
+	}
+}
+
+// handleConfigRestored watches the config file again once it reappears and
+// reloads it
+func (w *Watcher) handleConfigRestored(configPath string) {
+	if err := w.fsWatcher.Add(configPath); err != nil {
+		w.logger.Error("Failed to watch restored configuration file", "file", configPath, "error", err)
+		return
+	}
+	w.fsWatcher.Remove(filepath.Dir(configPath))
+
+	w.mu.Lock()
+	w.missing = false
+	w.mu.Unlock()
+
+	w.logger.Info("Configuration file restored, watching it again", "file", configPath)
+
+	// A freshly created file is often still empty; its content arrives as a write
+	if info, err := os.Stat(configPath); err == nil && info.Size() == 0 {
+		return
+	}
+
+	reloadID := uuid.NewString()
+	if err := w.reloadConfig(reloadID, ReloadTriggerFile); err != nil {
+		w.logger.Error("Failed to reload configuration", "reload_id", reloadID, "error", err)
 	}
 }
 
 // reloadConfig performs the actual configuration reload
-func (w *Watcher) reloadConfig() error {
+func (w *Watcher) reloadConfig(reloadID string, trigger ReloadTrigger) error {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	w.logger.Info("Reloading configuration", "file", w.configPath)
+	w.logger.Info("Reloading configuration", "reload_id", reloadID, "trigger", trigger, "file", w.configPath)
+
+	if w.missing {
+		return fmt.Errorf("reload %s: configuration file %s is missing, keeping last loaded configuration", reloadID, w.configPath)
+	}
 
 	// Load new configuration
 	newConfig, err := Load(w.configPath)
 	if err != nil {
-		return fmt.Errorf("failed to load new configuration: %w", err)
+		return fmt.Errorf("reload %s: failed to load new configuration: %w", reloadID, err)
 	}
 
-	// Update current config
-	// Note: We keep a reference to the old config for potential future rollback functionality
-	w.config = newConfig
-	w.lastReload = time.Now()
-
-	// Call all registered callbacks
+	// Call all registered callbacks. The new config only becomes current once
+	// they have run, so a reload stopped by a failing callback keeps the old one.
 	var callbackErrors []error
 	for i, callback := range w.callbacks {
-		if err := callback(newConfig); err != nil {
+		if err := w.runCallback(reloadID, i, callback, newConfig); err != nil {
 			w.logger.Error("Configuration reload callback failed",
-				"callback_index", i, "error", err)
+				"reload_id", reloadID, "callback_index", i, "error", err)
 			callbackErrors = append(callbackErrors, err)
+
+			if w.stopOnCallbackError {
+				w.logger.Warn("Stopping configuration reload after callback failure",
+					"reload_id", reloadID,
+					"skipped_callbacks", len(w.callbacks)-i-1)
+				return fmt.Errorf("reload %s: callback %d failed: %w", reloadID, i, err)
+			}
+			continue
 		}
+		w.logger.Debug("Configuration reload callback succeeded",
+			"reload_id", reloadID, "callback_index", i)
 	}
 
+	w.config = newConfig
+	w.lastReload = time.Now()
+
 	// If any callback failed, consider rolling back
 	if len(callbackErrors) > 0 {
 		w.logger.Warn("Some configuration reload callbacks failed, keeping new config but logging errors",
+			"reload_id", reloadID,
 			"failed_callbacks", len(callbackErrors),
 			"total_callbacks", len(w.callbacks))
 
@@ -259,12 +376,31 @@ func (w *Watcher) reloadConfig() error {
 	}
 
 	w.logger.Info("Configuration reloaded successfully",
+		"reload_id", reloadID,
+		"trigger", trigger,
 		"callbacks_executed", len(w.callbacks),
 		"callback_errors", len(callbackErrors))
 
 	return nil
 }
 
+// runCallback invokes a reload callback, converting a panic into an error so a
+// buggy callback cannot abort the reload or crash the watcher
+func (w *Watcher) runCallback(reloadID string, index int, callback ReloadCallback, config *Config) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			w.logger.Error("Configuration reload callback panicked",
+				"reload_id", reloadID,
+				"callback_index", index,
+				"panic", r,
+				"stack", string(debug.Stack()))
+			err = fmt.Errorf("callback panicked: %v", r)
+		}
+	}()
+
+	return callback(config)
+}
+
 // IsRunning returns whether the watcher is currently running
 func (w *Watcher) IsRunning() bool {
 	w.mu.RLock()
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestWatcher creates a watcher over a config file holding only defaults
+func newTestWatcher(t *testing.T, stopOnCallbackError bool) *Watcher {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("server:\n  name: test\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWatcher(path, &WatcherOptions{
+		Logger:              slog.New(slog.NewTextHandler(io.Discard, nil)),
+		StopOnCallbackError: stopOnCallbackError,
+	})
+	if err != nil {
+		t.Fatalf("NewWatcher: %v", err)
+	}
+	t.Cleanup(func() { w.fsWatcher.Close() })
+	return w
+}
+
+func TestReloadContinuesPastPanickingCallback(t *testing.T) {
+	w := newTestWatcher(t, false)
+	previous := w.GetConfig()
+
+	var ran []int
+	w.AddCallback(func(*Config) error { ran = append(ran, 0); return nil })
+	w.AddCallback(func(*Config) error { panic("broken callback") })
+	w.AddCallback(func(*Config) error { ran = append(ran, 2); return nil })
+
+	if err := w.reloadConfig("test", ReloadTriggerManual); err != nil {
+		t.Fatalf("reloadConfig: %v", err)
+	}
+	if len(ran) != 2 || ran[0] != 0 || ran[1] != 2 {
+		t.Errorf("callbacks run = %v, want [0 2]", ran)
+	}
+	if w.GetConfig() == previous {
+		t.Error("reload kept the old config after a non-fatal callback failure")
+	}
+}
+
+func TestReloadStopsOnCallbackError(t *testing.T) {
+	w := newTestWatcher(t, true)
+
+	failure := errors.New("callback failed")
+	previous := w.GetConfig()
+	var ran []int
+	w.AddCallback(func(*Config) error { ran = append(ran, 0); return failure })
+	w.AddCallback(func(*Config) error { ran = append(ran, 1); return nil })
+
+	err := w.reloadConfig("test", ReloadTriggerManual)
+	if !errors.Is(err, failure) {
+		t.Fatalf("reloadConfig error = %v, want %v", err, failure)
+	}
+	if len(ran) != 1 {
+		t.Errorf("callbacks run = %v, want [0]", ran)
+	}
+	if w.GetConfig() != previous {
+		t.Error("stopped reload replaced the current config")
+	}
+}
+
+func TestReloadStopsOnCallbackPanic(t *testing.T) {
+	w := newTestWatcher(t, true)
+
+	var ran []int
+	w.AddCallback(func(*Config) error { panic("broken callback") })
+	w.AddCallback(func(*Config) error { ran = append(ran, 1); return nil })
+
+	err := w.reloadConfig("test", ReloadTriggerManual)
+	if err == nil || !strings.Contains(err.Error(), "broken callback") {
+		t.Fatalf("reloadConfig error = %v, want the panic", err)
+	}
+	if len(ran) != 0 {
+		t.Errorf("callbacks run = %v, want none", ran)
+	}
+}
@@ -1,8 +1,10 @@
 package registry
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
 	"sync"
 	"time"
 
@@ -16,9 +18,15 @@ type Registry struct {
 	tools     map[string]mcpplugin.MCPToolPlugin
 	toolsLock sync.RWMutex
 
+	// Event subscribers
+	registerCallbacks   []mcpplugin.RegisterCallback
+	unregisterCallbacks []mcpplugin.UnregisterCallback
+	callbacksLock       sync.RWMutex
+
 	// Discovery state
 	discoveryEnabled bool
 	scanInterval     time.Duration
+	scanJitter       float64 // fraction of scanInterval each tick may vary by
 	directories      []string
 	stopDiscovery    chan struct{}
 	discoveryRunning bool
@@ -32,6 +40,7 @@ func NewRegistry(cfg *config.PluginsConfig) mcpplugin.ToolRegistry {
 		tools:            make(map[string]mcpplugin.MCPToolPlugin),
 		discoveryEnabled: cfg.Discovery.Enabled,
 		scanInterval:     cfg.Discovery.ScanInterval,
+		scanJitter:       cfg.Discovery.ScanJitter,
 		directories:      cfg.Discovery.Directories,
 		stopDiscovery:    make(chan struct{}),
 	}
@@ -49,45 +58,88 @@ func (r *Registry) RegisterTool(tool mcpplugin.MCPToolPlugin) error {
 	}
 
 	r.toolsLock.Lock()
-	defer r.toolsLock.Unlock()
 
 	// Check if tool already exists
 	if _, exists := r.tools[name]; exists {
+		r.toolsLock.Unlock()
 		return fmt.Errorf("tool already registered: %s", name)
 	}
 
 	// Initialize the tool
-	if err := tool.Initialize(); err != nil {
+	if err := mcpplugin.CallWithTimeout(name+" Initialize", r.config.Loading.LifecycleTimeout, tool.Initialize); err != nil {
+		r.toolsLock.Unlock()
 		return fmt.Errorf("failed to initialize tool %s: %w", name, err)
 	}
 
 	r.tools[name] = tool
+	r.toolsLock.Unlock()
+
 	slog.Info("Registered MCP tool", "name", name, "version", tool.Version(), "description", tool.Description())
 
+	// Notify subscribers outside the lock so they may query the registry
+	for _, callback := range r.getRegisterCallbacks() {
+		callback(tool)
+	}
+
 	return nil
 }
 
 // UnregisterTool unregisters an MCP tool plugin
 func (r *Registry) UnregisterTool(name string) error {
 	r.toolsLock.Lock()
-	defer r.toolsLock.Unlock()
 
 	tool, exists := r.tools[name]
 	if !exists {
+		r.toolsLock.Unlock()
 		return fmt.Errorf("tool not found: %s", name)
 	}
 
 	// Cleanup the tool
-	if err := tool.Cleanup(); err != nil {
+	if err := mcpplugin.CallWithTimeout(name+" Cleanup", r.config.Loading.LifecycleTimeout, tool.Cleanup); err != nil {
 		slog.Warn("Error cleaning up tool", "name", name, "error", err)
 	}
 
 	delete(r.tools, name)
+	r.toolsLock.Unlock()
+
 	slog.Info("Unregistered MCP tool", "name", name)
 
+	// Notify subscribers outside the lock so they may query the registry
+	for _, callback := range r.getUnregisterCallbacks() {
+		callback(name)
+	}
+
 	return nil
 }
 
+// OnRegister subscribes a callback to tool registrations
+func (r *Registry) OnRegister(callback mcpplugin.RegisterCallback) {
+	r.callbacksLock.Lock()
+	defer r.callbacksLock.Unlock()
+	r.registerCallbacks = append(r.registerCallbacks, callback)
+}
+
+// OnUnregister subscribes a callback to tool unregistrations
+func (r *Registry) OnUnregister(callback mcpplugin.UnregisterCallback) {
+	r.callbacksLock.Lock()
+	defer r.callbacksLock.Unlock()
+	r.unregisterCallbacks = append(r.unregisterCallbacks, callback)
+}
+
+// getRegisterCallbacks returns a snapshot of the registration subscribers
+func (r *Registry) getRegisterCallbacks() []mcpplugin.RegisterCallback {
+	r.callbacksLock.RLock()
+	defer r.callbacksLock.RUnlock()
+	return append([]mcpplugin.RegisterCallback(nil), r.registerCallbacks...)
+}
+
+// getUnregisterCallbacks returns a snapshot of the unregistration subscribers
+func (r *Registry) getUnregisterCallbacks() []mcpplugin.UnregisterCallback {
+	r.callbacksLock.RLock()
+	defer r.callbacksLock.RUnlock()
+	return append([]mcpplugin.UnregisterCallback(nil), r.unregisterCallbacks...)
+}
+
 // GetTool retrieves an MCP tool plugin by name
 func (r *Registry) GetTool(name string) (mcpplugin.MCPToolPlugin, error) {
 	r.toolsLock.RLock()
@@ -120,8 +172,9 @@ func (r *Registry) DiscoverTools() error {
 	return nil
 }
 
-// StartPeriodicDiscovery starts background plugin discovery
-func (r *Registry) StartPeriodicDiscovery() error {
+// StartPeriodicDiscovery starts background plugin discovery. It runs until
+// StopPeriodicDiscovery is called or ctx is done.
+func (r *Registry) StartPeriodicDiscovery(ctx context.Context) error {
 	r.discoveryMutex.Lock()
 	defer r.discoveryMutex.Unlock()
 
@@ -134,9 +187,9 @@ func (r *Registry) StartPeriodicDiscovery() error {
 	}
 
 	r.discoveryRunning = true
-	go r.periodicDiscoveryLoop()
+	go r.periodicDiscoveryLoop(ctx, r.stopDiscovery)
 
-	slog.Info("Started periodic plugin discovery", "interval", r.scanInterval)
+	slog.Info("Started periodic plugin discovery", "interval", r.scanInterval, "jitter", r.scanJitter)
 	return nil
 }
 
@@ -169,7 +222,7 @@ func (r *Registry) Shutdown() error {
 	defer r.toolsLock.Unlock()
 
 	for name, tool := range r.tools {
-		if err := tool.Cleanup(); err != nil {
+		if err := mcpplugin.CallWithTimeout(name+" Cleanup", r.config.Loading.LifecycleTimeout, tool.Cleanup); err != nil {
 			slog.Error("Error cleaning up tool", "name", name, "error", err)
 		}
 	}
@@ -180,23 +233,43 @@ func (r *Registry) Shutdown() error {
 	return nil
 }
 
-// periodicDiscoveryLoop runs the periodic discovery in background
-func (r *Registry) periodicDiscoveryLoop() {
-	ticker := time.NewTicker(r.scanInterval)
-	defer ticker.Stop()
+// periodicDiscoveryLoop runs the periodic discovery in background until stop is
+// closed or ctx is done
+func (r *Registry) periodicDiscoveryLoop(ctx context.Context, stop <-chan struct{}) {
+	timer := time.NewTimer(r.nextScanDelay())
+	defer timer.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-timer.C:
 			if err := r.DiscoverTools(); err != nil {
 				slog.Error("Error during periodic discovery", "error", err)
 			}
-		case <-r.stopDiscovery:
+			timer.Reset(r.nextScanDelay())
+		case <-stop:
+			return
+		case <-ctx.Done():
+			r.discoveryMutex.Lock()
+			if r.stopDiscovery == stop {
+				r.discoveryRunning = false
+				r.stopDiscovery = make(chan struct{})
+			}
+			r.discoveryMutex.Unlock()
 			return
 		}
 	}
 }
 
+// nextScanDelay returns the scan interval varied by a random amount within the
+// configured jitter, so servers started together drift apart
+func (r *Registry) nextScanDelay() time.Duration {
+	if r.scanJitter <= 0 {
+		return r.scanInterval
+	}
+	offset := (rand.Float64()*2 - 1) * r.scanJitter
+	return time.Duration(float64(r.scanInterval) * (1 + offset))
+}
+
 // getToolNames returns list of registered tool names
 func (r *Registry) getToolNames() []string {
 	r.toolsLock.RLock()
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditEntry describes a single completed tool call
+type AuditEntry struct {
+	Timestamp time.Time              `json:"timestamp"`
+	RequestID string                 `json:"request_id"`
+	ClientIP  string                 `json:"client_ip,omitempty"`
+	Tool      string                 `json:"tool"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	Duration  time.Duration          `json:"duration_ns"`
+	Success   bool                   `json:"success"`
+	Error     string                 `json:"error,omitempty"`
+}
+
+// AuditSink receives an audit record after every tool call.
+// Implementations must be safe for concurrent use.
+type AuditSink interface {
+	Record(entry AuditEntry)
+}
+
+// NopAuditSink discards all audit records
+type NopAuditSink struct{}
+
+// Record implements AuditSink
+func (NopAuditSink) Record(AuditEntry) {}
+
+// SlogAuditSink writes audit records to a structured logger
+type SlogAuditSink struct {
+	logger *slog.Logger
+}
+
+// NewSlogAuditSink creates an audit sink backed by the given logger
+func NewSlogAuditSink(logger *slog.Logger) *SlogAuditSink {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogAuditSink{logger: logger}
+}
+
+// Record implements AuditSink
+func (s *SlogAuditSink) Record(entry AuditEntry) {
+	s.logger.Info("Tool call audit",
+		"request_id", entry.RequestID,
+		"client_ip", entry.ClientIP,
+		"tool", entry.Tool,
+		"arguments", entry.Arguments,
+		"duration", entry.Duration,
+		"success", entry.Success,
+		"error", entry.Error)
+}
+
+// FileAuditSink appends audit records to a file as JSON lines
+type FileAuditSink struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// NewFileAuditSink opens (or creates) the audit file for appending
+func NewFileAuditSink(path string) (*FileAuditSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit file: %w", err)
+	}
+
+	return &FileAuditSink{
+		file: file,
+		enc:  json.NewEncoder(file),
+	}, nil
+}
+
+// Record implements AuditSink
+func (s *FileAuditSink) Record(entry AuditEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.enc.Encode(entry); err != nil {
+		slog.Error("Failed to write audit record", "error", err)
+	}
+}
+
+// Close closes the underlying file
+func (s *FileAuditSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,170 @@
+package server
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/eadydb/zephyr/pkg/plugin"
+)
+
+// DefaultCacheMaxEntries bounds the response cache when no limit is configured
+const DefaultCacheMaxEntries = 1000
+
+// cacheEntry is a successful tool result and when it stops being served
+type cacheEntry struct {
+	key     string
+	result  interface{}
+	expires time.Time
+}
+
+// CacheStats describes the response cache for metrics
+type CacheStats struct {
+	Size        int   `json:"size"`
+	MaxEntries  int   `json:"max_entries"`
+	Evictions   int64 `json:"evictions"`   // entries dropped to stay within MaxEntries
+	Expirations int64 `json:"expirations"` // entries dropped once their TTL passed
+}
+
+// responseCache serves repeated calls to idempotent tools from memory. Only
+// tools with a TTL are cached, keyed by tool name and normalized arguments.
+// It holds at most maxEntries results, evicting the least recently used.
+type responseCache struct {
+	mu         sync.Mutex
+	ttls       map[string]time.Duration
+	maxEntries int
+	order      *list.List               // front is most recently used
+	entries    map[string]*list.Element // key -> element holding a *cacheEntry
+
+	evictions   int64
+	expirations int64
+}
+
+// newResponseCache creates a cache for the tools with a positive TTL. A
+// non-positive maxEntries uses DefaultCacheMaxEntries.
+func newResponseCache(ttls map[string]time.Duration, maxEntries int) *responseCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultCacheMaxEntries
+	}
+
+	cache := &responseCache{
+		ttls:       make(map[string]time.Duration),
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+	for tool, ttl := range ttls {
+		if ttl > 0 {
+			cache.ttls[tool] = ttl
+		}
+	}
+	return cache
+}
+
+// enabled reports whether calls to the tool are cached
+func (c *responseCache) enabled(toolName string) bool {
+	if c == nil {
+		return false
+	}
+	_, exists := c.ttls[toolName]
+	return exists
+}
+
+// cacheKey builds the key for a call. Marshalling sorts map keys, so argument
+// order does not matter.
+func cacheKey(toolName string, args map[string]interface{}) (string, bool) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", false
+	}
+	return toolName + "\x00" + string(data), true
+}
+
+// get returns a cached result that has not yet expired
+func (c *responseCache) get(toolName string, args map[string]interface{}) (interface{}, bool) {
+	key, ok := cacheKey(toolName, args)
+	if !ok {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		return nil, false
+	}
+
+	entry := element.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.removeLocked(element)
+		c.expirations++
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.result, true
+}
+
+// put stores a successful result for the tool's TTL, evicting the least
+// recently used entries beyond the size bound
+func (c *responseCache) put(toolName string, args map[string]interface{}, result interface{}) {
+	key, ok := cacheKey(toolName, args)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := time.Now().Add(c.ttls[toolName])
+	if element, exists := c.entries[key]; exists {
+		entry := element.Value.(*cacheEntry)
+		entry.result, entry.expires = result, expires
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, result: result, expires: expires})
+
+	for c.order.Len() > c.maxEntries {
+		c.removeLocked(c.order.Back())
+		c.evictions++
+	}
+}
+
+// removeLocked drops an entry. The caller must hold c.mu.
+func (c *responseCache) removeLocked(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*cacheEntry).key)
+}
+
+// stats returns the cache size and eviction counters
+func (c *responseCache) stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return CacheStats{
+		Size:        c.order.Len(),
+		MaxEntries:  c.maxEntries,
+		Evictions:   c.evictions,
+		Expirations: c.expirations,
+	}
+}
+
+// cacheable reports whether a call may be served from or stored in the cache.
+// Dry runs describe a change rather than produce a result, so they are never cached.
+func (c *responseCache) cacheable(toolName string, args map[string]interface{}) bool {
+	return c.enabled(toolName) && !plugin.IsDryRun(args)
+}
+
+// SetResponseCache enables response caching for the tools with a positive TTL.
+// Successful results are reused for identical arguments until the TTL expires;
+// at most maxEntries results are kept. It must be called before Start.
+func (s *Server) SetResponseCache(ttls map[string]time.Duration, maxEntries int) {
+	s.cache = newResponseCache(ttls, maxEntries)
+	if s.metrics != nil {
+		s.metrics.SetCacheStats(s.cache.stats)
+	}
+}
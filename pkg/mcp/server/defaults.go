@@ -0,0 +1,71 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/eadydb/zephyr/pkg/plugin"
+)
+
+// schemaDefaults collects the default value of each input property that
+// declares one. Values are kept JSON-encoded so every call decodes a fresh
+// copy shaped like client arguments. Meta-arguments are handled by the server
+// and never defaulted.
+func schemaDefaults(toolName string, props map[string]interface{}) map[string]json.RawMessage {
+	var defaults map[string]json.RawMessage
+	for name, prop := range props {
+		if name == plugin.DryRunArg || name == plugin.PrettyArg {
+			continue
+		}
+		schema, ok := prop.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, ok := schema["default"]
+		if !ok {
+			continue
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			slog.Warn("Ignoring tool argument default that cannot be encoded",
+				"tool", toolName,
+				"argument", name,
+				"error", err)
+			continue
+		}
+		if defaults == nil {
+			defaults = make(map[string]json.RawMessage)
+		}
+		defaults[name] = encoded
+	}
+	return defaults
+}
+
+// withDefaults returns the arguments with every missing defaulted property
+// filled in. The caller's map is never modified.
+func withDefaults(args map[string]interface{}, defaults map[string]json.RawMessage) map[string]interface{} {
+	var filled map[string]interface{}
+	for name, encoded := range defaults {
+		if _, present := args[name]; present {
+			continue
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(encoded, &value); err != nil {
+			continue
+		}
+		if filled == nil {
+			filled = make(map[string]interface{}, len(args)+len(defaults))
+			for k, v := range args {
+				filled[k] = v
+			}
+		}
+		filled[name] = value
+	}
+
+	if filled == nil {
+		return args
+	}
+	return filled
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/eadydb/zephyr/pkg/plugin"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// JSON-RPC error codes reported for failed tool calls. Codes in the -32000 to
+// -32099 range are reserved for implementation-defined server errors.
+const (
+	CodeInvalidParams        = mcp.INVALID_PARAMS
+	CodeInternalError        = mcp.INTERNAL_ERROR
+	CodeRateLimited          = -32001
+	CodeTimeout              = -32002
+	CodeToolPanic            = -32003
+	CodeMemoryBudgetExceeded = -32004
+	CodeDryRunNotSupported   = -32005
+	CodeToolCancelled        = -32006
+)
+
+var (
+	// ErrRateLimited is returned when a call is rejected by the rate limiter
+	ErrRateLimited = errors.New("rate limit exceeded")
+
+	// ErrDryRunNotSupported is returned when a dry run is requested from a tool that cannot honor it
+	ErrDryRunNotSupported = errors.New("dry run not supported")
+
+	// ErrMemoryBudgetExceeded is returned when a call allocates more than the per-call budget
+	ErrMemoryBudgetExceeded = errors.New("memory budget exceeded")
+
+	// ErrToolPanic is returned when a tool panics during execution
+	ErrToolPanic = errors.New("tool panicked")
+)
+
+// errorCode maps an error from a tool call to a JSON-RPC error code
+func errorCode(err error) int {
+	var toolErr *plugin.ToolError
+	if errors.As(err, &toolErr) && toolErr.Code != 0 {
+		return toolErr.Code
+	}
+
+	switch {
+	case errors.Is(err, plugin.ErrInvalidArguments):
+		return CodeInvalidParams
+	case errors.Is(err, ErrRateLimited):
+		return CodeRateLimited
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeTimeout
+	case errors.Is(err, context.Canceled):
+		return CodeToolCancelled
+	case errors.Is(err, ErrToolPanic):
+		return CodeToolPanic
+	case errors.Is(err, ErrMemoryBudgetExceeded):
+		return CodeMemoryBudgetExceeded
+	case errors.Is(err, ErrDryRunNotSupported):
+		return CodeDryRunNotSupported
+	default:
+		return CodeInternalError
+	}
+}
+
+// errorResult builds the result of a failed tool call. The message is returned as
+// text for humans, and a JSON-RPC style error object with a machine-readable code
+// is attached under _meta.error, since tool failures are reported in the result
+// rather than as a protocol error.
+func errorResult(toolName, requestID string, err error) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Result: mcp.Result{
+			Meta: map[string]any{
+				"error": map[string]any{
+					"code":    errorCode(err),
+					"message": err.Error(),
+					"data": map[string]any{
+						"tool":       toolName,
+						"request_id": requestID,
+					},
+				},
+			},
+		},
+		Content: []mcp.Content{
+			mcp.NewTextContent(fmt.Sprintf("Error executing tool %s (request_id: %s): %v", toolName, requestID, err)),
+		},
+		IsError: true,
+	}
+}
+
+// executeWithRecovery runs the tool and, if it reports that its plugin is not
+// initialized, reinitializes the plugin once and retries the call
+func executeWithRecovery(ctx context.Context, tool plugin.MCPToolPlugin, input map[string]interface{}, requestID string) (interface{}, error) {
+	result, err := executeTool(ctx, tool, input)
+	if !plugin.IsNotInitialized(err) {
+		return result, err
+	}
+
+	reinitializer, ok := tool.(plugin.Reinitializer)
+	if !ok {
+		return result, err
+	}
+
+	slog.Warn("Tool reported its plugin is not initialized, reinitializing",
+		"tool", tool.Name(),
+		"request_id", requestID)
+	if initErr := reinitializer.Reinitialize(); initErr != nil {
+		slog.Error("Failed to reinitialize plugin",
+			"tool", tool.Name(),
+			"request_id", requestID,
+			"error", initErr)
+		return result, err
+	}
+
+	slog.Info("Recovered uninitialized plugin", "tool", tool.Name(), "request_id", requestID)
+	return executeTool(ctx, tool, input)
+}
+
+// executeTool runs the tool, converting a panic into an ErrToolPanic error
+func executeTool(ctx context.Context, tool plugin.MCPToolPlugin, input map[string]interface{}) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("%w: %v", ErrToolPanic, r)
+		}
+	}()
+
+	return tool.Execute(ctx, input)
+}
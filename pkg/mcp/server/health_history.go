@@ -0,0 +1,60 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// DefaultHealthHistorySize is how many health transitions are kept when no size is configured
+const DefaultHealthHistorySize = 50
+
+// HealthTransition is a change in the status reported by the health check
+type HealthTransition struct {
+	Timestamp time.Time `json:"timestamp"`
+	Status    string    `json:"status"`
+	Healthy   bool      `json:"healthy"`
+}
+
+// recordHealth appends a transition when the status differs from the last one
+// reported, dropping the oldest once the history is full
+func (m *MetricsCollector) recordHealth(status string, healthy bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if n := len(m.healthHistory); n > 0 && m.healthHistory[n-1].Status == status {
+		return
+	}
+
+	m.healthHistory = append(m.healthHistory, HealthTransition{
+		Timestamp: time.Now(),
+		Status:    status,
+		Healthy:   healthy,
+	})
+	if len(m.healthHistory) > m.healthHistorySize {
+		m.healthHistory = append(m.healthHistory[:0], m.healthHistory[len(m.healthHistory)-m.healthHistorySize:]...)
+	}
+}
+
+// HealthHistory returns the recent health transitions, oldest first. Status is
+// only evaluated when the health endpoint is probed, so changes between probes
+// are not seen.
+func (m *MetricsCollector) HealthHistory() []HealthTransition {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]HealthTransition{}, m.healthHistory...)
+}
+
+// healthHistoryHandler serves the recent health transitions
+func (m *MetricsCollector) healthHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"transitions": m.HealthHistory(),
+		"capacity":    m.healthHistorySize,
+	})
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+)
+
+// memoryBudget is a soft per-call allocation limit. It cannot cap memory inside
+// the process; it only flags, and optionally fails, calls that allocate too much.
+type memoryBudget struct {
+	maxBytes uint64 // 0 disables the check
+	reject   bool
+}
+
+// memorySample is the allocation counter captured before a call
+type memorySample struct {
+	totalAlloc uint64
+	enabled    bool
+}
+
+// SetMemoryBudget sets the per-call allocation budget in bytes. Calls over budget
+// are logged and counted in metrics, and failed as well when reject is true.
+// A zero budget disables the check, which is the default since sampling stops the world.
+func (s *Server) SetMemoryBudget(maxBytes uint64, reject bool) {
+	s.memory = memoryBudget{maxBytes: maxBytes, reject: reject}
+}
+
+// start samples the allocation counter if the budget is enabled
+func (b memoryBudget) start() memorySample {
+	if b.maxBytes == 0 {
+		return memorySample{}
+	}
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return memorySample{totalAlloc: stats.TotalAlloc, enabled: true}
+}
+
+// checkMemoryBudget compares the bytes allocated since sample against the budget.
+// It returns an error only when the call exceeded the budget and rejection is enabled.
+// Allocations are process-wide, so concurrent calls can push an innocent tool over.
+func (s *Server) checkMemoryBudget(sample memorySample, toolName, requestID string) error {
+	if !sample.enabled {
+		return nil
+	}
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	allocated := stats.TotalAlloc - sample.totalAlloc
+	if allocated <= s.memory.maxBytes {
+		return nil
+	}
+
+	slog.Warn("Tool exceeded memory budget",
+		"tool", toolName,
+		"request_id", requestID,
+		"allocated_bytes", allocated,
+		"budget_bytes", s.memory.maxBytes,
+		"rejected", s.memory.reject)
+
+	if s.metrics != nil {
+		s.metrics.RecordMemoryExceeded(toolName)
+	}
+
+	if s.memory.reject {
+		return fmt.Errorf("%w: tool %s allocated %d bytes, over the per-call budget of %d bytes",
+			ErrMemoryBudgetExceeded, toolName, allocated, s.memory.maxBytes)
+	}
+	return nil
+}
@@ -4,11 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"math"
 	"net/http"
+	"net/http/pprof"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/eadydb/zephyr/pkg/plugin"
 )
 
 // MetricsCollector handles server metrics collection
@@ -21,25 +27,228 @@ type MetricsCollector struct {
 	errorCount    int64
 	toolCallCount map[string]int64
 
+	// Calls that allocated more than the per-call memory budget, by tool
+	memoryExceeded map[string]int64
+
+	// Calls rejected by the rate limiter, by tool
+	throttled map[string]int64
+
+	// Response cache lookups, by tool
+	cacheHits   map[string]int64
+	cacheMisses map[string]int64
+	cacheStats  func() CacheStats // nil until a response cache is configured
+
+	// Outcomes of the most recent requests, true for errors, so the error
+	// rate reflects current conditions rather than the whole uptime
+	recentErrors     []bool
+	recentErrorCount int
+
 	// Performance metrics
 	avgResponseTime time.Duration
 	responseTimes   []time.Duration
 	maxResponseTime time.Duration
 
 	// System metrics
-	memoryStats runtime.MemStats
-	goroutines  int
+	memoryStats    runtime.MemStats
+	memoryStatsAge time.Duration // age of the shared snapshot when it was taken
+	goroutines     int
+
+	// Duration histogram over the whole uptime
+	histogramBuckets []time.Duration // upper bounds, ascending
+	histogramCounts  []int64         // one per bucket plus a final +Inf bucket
+	histogramSum     time.Duration
+
+	// Plugin manager backing the /plugins endpoints
+	pluginManager *plugin.PluginManager
+
+	// Tool registry backing the /tools endpoint
+	toolRegistry plugin.ToolRegistry
+
+	// Reports the health of each running transport, keyed by protocol
+	transportHealth func() map[string]bool
+
+	// Set once shutdown begins; the health endpoint then reports draining
+	draining bool
+
+	// Most recent changes in health status, oldest first, capped at healthHistorySize
+	healthHistory     []HealthTransition
+	healthHistorySize int
+
+	// Grace period for in-flight requests when the metrics server stops
+	shutdownTimeout time.Duration
+
+	// Routes of the metrics and health endpoints
+	metricsPath string
+	healthPath  string
+
+	// Origins allowed to make cross-origin requests; empty disables CORS
+	corsOrigins []string
+
+	// Serve Go profiling endpoints
+	pprof bool
+
+	// Goroutine count above which health reports degraded, and whether it is
+	// currently exceeded so the warning is logged once per excursion
+	maxGoroutines     int
+	goroutineExceeded bool
+}
+
+// recentWindowSize is how many of the latest requests the response time and
+// error rate windows keep
+const recentWindowSize = 1000
+
+// DefaultHistogramBuckets are the tool call duration bucket upper bounds used when none are configured
+var DefaultHistogramBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// MetricsOptions holds optional configuration for the metrics collector
+type MetricsOptions struct {
+	// HistogramBuckets are ascending upper bounds for the duration histogram
+	HistogramBuckets []time.Duration
+
+	// ShutdownTimeout bounds how long the metrics server drains on shutdown
+	ShutdownTimeout time.Duration
+
+	// MetricsPath and HealthPath are the routes of the metrics server, defaulting to /metrics and /health
+	MetricsPath string
+	HealthPath  string
+
+	// PProf mounts the net/http/pprof handlers under /debug/pprof/
+	PProf bool
+
+	// MaxGoroutines marks the server degraded while more goroutines are running; 0 disables
+	MaxGoroutines int
+
+	// HealthHistorySize is how many health transitions are kept, defaulting to DefaultHealthHistorySize
+	HealthHistorySize int
+
+	// CORSOrigins lists the origins browsers may read the endpoints from; "*"
+	// allows any. Empty disables CORS.
+	CORSOrigins []string
 }
 
 // NewMetricsCollector creates a new metrics collector
 func NewMetricsCollector() *MetricsCollector {
+	return NewMetricsCollectorWithOptions(nil)
+}
+
+// NewMetricsCollectorWithOptions creates a new metrics collector with custom options
+func NewMetricsCollectorWithOptions(opts *MetricsOptions) *MetricsCollector {
+	if opts == nil {
+		opts = &MetricsOptions{}
+	}
+
+	// Set defaults
+	buckets := opts.HistogramBuckets
+	if len(buckets) == 0 {
+		buckets = DefaultHistogramBuckets
+	}
+	buckets = append([]time.Duration(nil), buckets...)
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+
+	shutdownTimeout := opts.ShutdownTimeout
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = 5 * time.Second
+	}
+
+	metricsPath := opts.MetricsPath
+	if metricsPath == "" {
+		metricsPath = "/metrics"
+	}
+
+	healthPath := opts.HealthPath
+	if healthPath == "" {
+		healthPath = "/health"
+	}
+
+	healthHistorySize := opts.HealthHistorySize
+	if healthHistorySize <= 0 {
+		healthHistorySize = DefaultHealthHistorySize
+	}
+
 	return &MetricsCollector{
-		startTime:     time.Now(),
-		toolCallCount: make(map[string]int64),
-		responseTimes: make([]time.Duration, 0, 1000), // Keep last 1000 response times
+		startTime:         time.Now(),
+		toolCallCount:     make(map[string]int64),
+		memoryExceeded:    make(map[string]int64),
+		throttled:         make(map[string]int64),
+		cacheHits:         make(map[string]int64),
+		cacheMisses:       make(map[string]int64),
+		responseTimes:     make([]time.Duration, 0, recentWindowSize),
+		recentErrors:      make([]bool, 0, recentWindowSize),
+		histogramBuckets:  buckets,
+		histogramCounts:   make([]int64, len(buckets)+1),
+		shutdownTimeout:   shutdownTimeout,
+		metricsPath:       metricsPath,
+		healthPath:        healthPath,
+		healthHistorySize: healthHistorySize,
+		corsOrigins:       opts.CORSOrigins,
+		maxGoroutines:     opts.MaxGoroutines,
+		pprof:             opts.PProf,
 	}
 }
 
+// SetPluginManager sets the plugin manager used by the plugin endpoints
+func (m *MetricsCollector) SetPluginManager(pm *plugin.PluginManager) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pluginManager = pm
+}
+
+// SetToolRegistry sets the registry whose tools the /tools endpoint describes
+func (m *MetricsCollector) SetToolRegistry(registry plugin.ToolRegistry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.toolRegistry = registry
+}
+
+// SetTransportHealth sets the function reporting per-transport health for the health endpoint
+func (m *MetricsCollector) SetTransportHealth(fn func() map[string]bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.transportHealth = fn
+}
+
+// SetDraining marks the server as shutting down so health checks fail fast
+func (m *MetricsCollector) SetDraining(draining bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.draining = draining
+}
+
+// getPluginManager returns the configured plugin manager (thread-safe)
+func (m *MetricsCollector) getPluginManager() *plugin.PluginManager {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.pluginManager
+}
+
+// getToolRegistry returns the configured tool registry (thread-safe)
+func (m *MetricsCollector) getToolRegistry() plugin.ToolRegistry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.toolRegistry
+}
+
+// recentErrorRate returns the error rate over the recent request window. The
+// caller must hold m.mu.
+func (m *MetricsCollector) recentErrorRate() float64 {
+	if len(m.recentErrors) == 0 {
+		return 0
+	}
+	return float64(m.recentErrorCount) / float64(len(m.recentErrors))
+}
+
 // RecordRequest records a request with its response time
 func (m *MetricsCollector) RecordRequest(duration time.Duration, toolName string, isError bool) {
 	m.mu.Lock()
@@ -56,8 +265,20 @@ func (m *MetricsCollector) RecordRequest(duration time.Duration, toolName string
 
 	// Update response times
 	m.responseTimes = append(m.responseTimes, duration)
-	if len(m.responseTimes) > 1000 {
-		m.responseTimes = m.responseTimes[1:] // Keep only last 1000
+	if len(m.responseTimes) > recentWindowSize {
+		m.responseTimes = m.responseTimes[1:]
+	}
+
+	// Update the recent error window
+	m.recentErrors = append(m.recentErrors, isError)
+	if isError {
+		m.recentErrorCount++
+	}
+	if len(m.recentErrors) > recentWindowSize {
+		if m.recentErrors[0] {
+			m.recentErrorCount--
+		}
+		m.recentErrors = m.recentErrors[1:]
 	}
 
 	// Update max response time
@@ -65,6 +286,13 @@ func (m *MetricsCollector) RecordRequest(duration time.Duration, toolName string
 		m.maxResponseTime = duration
 	}
 
+	// Update histogram; the first bucket whose bound is >= duration, or +Inf
+	bucket := sort.Search(len(m.histogramBuckets), func(i int) bool {
+		return m.histogramBuckets[i] >= duration
+	})
+	m.histogramCounts[bucket]++
+	m.histogramSum += duration
+
 	// Calculate average response time
 	var total time.Duration
 	for _, rt := range m.responseTimes {
@@ -73,28 +301,83 @@ func (m *MetricsCollector) RecordRequest(duration time.Duration, toolName string
 	m.avgResponseTime = total / time.Duration(len(m.responseTimes))
 }
 
+// RecordMemoryExceeded records a tool call that went over the per-call memory budget
+func (m *MetricsCollector) RecordMemoryExceeded(toolName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.memoryExceeded[toolName]++
+}
+
+// RecordThrottled records a tool call rejected by the rate limiter
+func (m *MetricsCollector) RecordThrottled(toolName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.throttled[toolName]++
+}
+
+// SetCacheStats sets the function reporting the response cache's size and evictions
+func (m *MetricsCollector) SetCacheStats(fn func() CacheStats) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cacheStats = fn
+}
+
+// RecordCacheLookup records whether a cacheable tool call was served from the response cache
+func (m *MetricsCollector) RecordCacheLookup(toolName string, hit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if hit {
+		m.cacheHits[toolName]++
+	} else {
+		m.cacheMisses[toolName]++
+	}
+}
+
 // UpdateSystemMetrics updates system-level metrics
 func (m *MetricsCollector) UpdateSystemMetrics() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	runtime.ReadMemStats(&m.memoryStats)
+	m.memoryStats, m.memoryStatsAge = plugin.ReadMemStats()
 	m.goroutines = runtime.NumGoroutine()
 }
 
 // GetMetrics returns current metrics as a map
 func (m *MetricsCollector) GetMetrics() map[string]interface{} {
+	// Query collaborators before taking the lock so a busy plugin manager or
+	// cache cannot hold up RecordRequest
+	m.mu.RLock()
+	cacheStats := m.cacheStats
+	pluginManager := m.pluginManager
+	m.mu.RUnlock()
+
+	var stats *CacheStats
+	if cacheStats != nil {
+		current := cacheStats()
+		stats = &current
+	}
+	var lifecycle map[string]plugin.PluginLifecycleStats
+	if pluginManager != nil {
+		lifecycle = pluginManager.LifecycleStats()
+	}
+
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	uptime := time.Since(m.startTime)
 
-	// Calculate error rate safely
-	errorRate := 0.0
+	latencies := append([]time.Duration(nil), m.responseTimes...)
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	// Calculate error rates safely
+	lifetimeErrorRate := 0.0
 	requestsPerSec := 0.0
 
 	if m.requestCount > 0 {
-		errorRate = float64(m.errorCount) / float64(m.requestCount)
+		lifetimeErrorRate = float64(m.errorCount) / float64(m.requestCount)
 		requestsPerSec = float64(m.requestCount) / uptime.Seconds()
 	}
 
@@ -104,15 +387,23 @@ func (m *MetricsCollector) GetMetrics() map[string]interface{} {
 			"start_time":       m.startTime.Format(time.RFC3339),
 			"request_count":    m.requestCount,
 			"error_count":      m.errorCount,
-			"error_rate":       errorRate,
 			"requests_per_sec": requestsPerSec,
+
+			// error_rate covers the last error_rate_window requests
+			"error_rate":          m.recentErrorRate(),
+			"error_rate_window":   len(m.recentErrors),
+			"lifetime_error_rate": lifetimeErrorRate,
 		},
 		"performance": map[string]interface{}{
 			"avg_response_time_ms": m.avgResponseTime.Milliseconds(),
 			"max_response_time_ms": m.maxResponseTime.Milliseconds(),
 			"total_requests":       len(m.responseTimes),
+			"p50_response_time_ms": Percentile(latencies, 50).Milliseconds(),
+			"p95_response_time_ms": Percentile(latencies, 95).Milliseconds(),
+			"p99_response_time_ms": Percentile(latencies, 99).Milliseconds(),
 		},
-		"tools": m.toolCallCount,
+		"histogram": m.histogramSnapshot(),
+		"tools":     copyCounts(m.toolCallCount),
 		"system": map[string]interface{}{
 			"goroutines":      m.goroutines,
 			"memory_alloc":    m.memoryStats.Alloc,
@@ -120,12 +411,91 @@ func (m *MetricsCollector) GetMetrics() map[string]interface{} {
 			"memory_heap":     m.memoryStats.HeapAlloc,
 			"memory_heap_sys": m.memoryStats.HeapSys,
 			"gc_cycles":       m.memoryStats.NumGC,
+
+			"memory_stats_age_ms": float64(m.memoryStatsAge.Microseconds()) / 1000,
+		},
+		"memory_budget_exceeded": copyCounts(m.memoryExceeded),
+		"throttled":              copyCounts(m.throttled),
+		"cache": map[string]interface{}{
+			"hits":   copyCounts(m.cacheHits),
+			"misses": copyCounts(m.cacheMisses),
 		},
 	}
 
+	if stats != nil {
+		cache := metrics["cache"].(map[string]interface{})
+		cache["size"] = stats.Size
+		cache["max_entries"] = stats.MaxEntries
+		cache["evictions"] = stats.Evictions
+		cache["expirations"] = stats.Expirations
+	}
+
+	if pluginManager != nil {
+		metrics["plugin_lifecycle"] = lifecycle
+	}
+
 	return metrics
 }
 
+// copyCounts copies a per-tool counter map so callers can encode it after the
+// lock is released
+func copyCounts(counts map[string]int64) map[string]int64 {
+	copied := make(map[string]int64, len(counts))
+	for tool, count := range counts {
+		copied[tool] = count
+	}
+	return copied
+}
+
+// histogramSnapshot returns the duration histogram with cumulative bucket counts.
+// Caller must hold m.mu.
+func (m *MetricsCollector) histogramSnapshot() map[string]interface{} {
+	buckets := make([]map[string]interface{}, 0, len(m.histogramCounts))
+
+	var cumulative int64
+	for i, count := range m.histogramCounts {
+		cumulative += count
+
+		le := "+Inf"
+		if i < len(m.histogramBuckets) {
+			le = strconv.FormatFloat(float64(m.histogramBuckets[i])/float64(time.Millisecond), 'f', -1, 64)
+		}
+
+		buckets = append(buckets, map[string]interface{}{
+			"le_ms": le,
+			"count": cumulative,
+		})
+	}
+
+	return map[string]interface{}{
+		"buckets": buckets,
+		"count":   cumulative,
+		"sum_ms":  float64(m.histogramSum) / float64(time.Millisecond),
+	}
+}
+
+// Percentile returns the p-th percentile (0-100) of durations sorted in ascending
+// order using the nearest-rank method, or 0 for an empty slice
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// msToDuration converts fractional milliseconds to a duration
+func msToDuration(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
 // ServeHTTP implements http.Handler for metrics endpoint
 func (m *MetricsCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -145,6 +515,27 @@ func (m *MetricsCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// checkGoroutines refreshes the goroutine count and reports whether it is above
+// the configured threshold, logging a warning when it first crosses it
+func (m *MetricsCollector) checkGoroutines() (int, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.goroutines = runtime.NumGoroutine()
+	if m.maxGoroutines <= 0 {
+		return m.goroutines, false
+	}
+
+	exceeded := m.goroutines > m.maxGoroutines
+	if exceeded && !m.goroutineExceeded {
+		slog.Warn("Goroutine count above threshold, possible leak",
+			"goroutines", m.goroutines,
+			"threshold", m.maxGoroutines)
+	}
+	m.goroutineExceeded = exceeded
+	return m.goroutines, exceeded
+}
+
 // HealthCheck provides a simple health check endpoint
 func (m *MetricsCollector) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -152,27 +543,56 @@ func (m *MetricsCollector) HealthCheck(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	goroutines, goroutinesDegraded := m.checkGoroutines()
+
 	m.mu.RLock()
 	uptime := time.Since(m.startTime)
-	requestCount := m.requestCount
-	errorCount := m.errorCount
+	recentRequests := len(m.recentErrors)
+	recentErrorRate := m.recentErrorRate()
+	transportHealth := m.transportHealth
+	draining := m.draining
 	m.mu.RUnlock()
 
 	// Simple health criteria
 	healthy := true
 	status := "healthy"
 
-	// Check error rate (unhealthy if > 50% errors in last 100 requests)
-	if requestCount > 100 && float64(errorCount)/float64(requestCount) > 0.5 {
+	// Check error rate (unhealthy if > 50% of recent requests failed, once there are enough to judge)
+	if recentRequests > 100 && recentErrorRate > 0.5 {
 		healthy = false
 		status = "unhealthy - high error rate"
 	}
 
+	// Every running transport must be healthy
+	var transports map[string]bool
+	if transportHealth != nil {
+		transports = transportHealth()
+		for _, ok := range transports {
+			if !ok && healthy {
+				healthy = false
+				status = "unhealthy - transport down"
+			}
+		}
+	}
+
+	// A likely goroutine leak degrades the server without taking it out of rotation
+	if goroutinesDegraded && healthy {
+		status = "degraded - goroutine count above threshold"
+	}
+
 	// Check if server has been running for at least 10 seconds
 	if uptime < 10*time.Second {
 		status = "starting"
 	}
 
+	// A draining server should be taken out of rotation
+	if draining {
+		healthy = false
+		status = "draining"
+	}
+
+	m.recordHealth(status, healthy)
+
 	w.Header().Set("Content-Type", "application/json")
 
 	response := map[string]interface{}{
@@ -182,6 +602,19 @@ func (m *MetricsCollector) HealthCheck(w http.ResponseWriter, r *http.Request) {
 		"version":   "1.0.0",
 		"timestamp": time.Now().Format(time.RFC3339),
 	}
+	if transports != nil {
+		response["transports"] = transports
+	}
+	if m.maxGoroutines > 0 {
+		response["goroutines"] = map[string]interface{}{
+			"count":     goroutines,
+			"threshold": m.maxGoroutines,
+			"degraded":  goroutinesDegraded,
+		}
+	}
+	if r.URL.Query().Get("history") == "true" {
+		response["history"] = m.HealthHistory()
+	}
 
 	statusCode := http.StatusOK
 	if !healthy {
@@ -192,22 +625,39 @@ func (m *MetricsCollector) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// StartMetricsServer starts an HTTP server for metrics and health endpoints
+// StartMetricsServer starts an HTTP server for metrics and health endpoints.
+// It blocks until ctx is cancelled and the server has finished draining.
 func (m *MetricsCollector) StartMetricsServer(ctx context.Context, addr string) error {
 	mux := http.NewServeMux()
 
 	// Existing endpoints
-	mux.HandleFunc("/health", m.HealthCheck)
-	mux.HandleFunc("/metrics", m.ServeHTTP)
+	mux.HandleFunc(m.healthPath, m.HealthCheck)
+	mux.HandleFunc(m.healthPath+"/history", m.healthHistoryHandler)
+	mux.HandleFunc(m.metricsPath, m.ServeHTTP)
 
 	// New plugin management endpoints
 	mux.HandleFunc("/plugins", m.pluginListHandler)
 	mux.HandleFunc("/plugins/", m.pluginDetailHandler)
 	mux.HandleFunc("/plugins/reload", m.pluginReloadHandler)
+	mux.HandleFunc("/tools", m.toolListHandler)
+
+	// Profiling endpoints expose internals, so they are opt-in
+	if m.pprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+
+	var handler http.Handler = mux
+	if len(m.corsOrigins) > 0 {
+		handler = m.corsMiddleware(mux)
+	}
 
 	server := &http.Server{
 		Addr:    addr,
-		Handler: mux,
+		Handler: handler,
 	}
 
 	// Start server in goroutine
@@ -221,12 +671,44 @@ func (m *MetricsCollector) StartMetricsServer(ctx context.Context, addr string)
 	<-ctx.Done()
 
 	// Graceful shutdown
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), m.shutdownTimeout)
 	defer cancel()
 
 	return server.Shutdown(shutdownCtx)
 }
 
+// corsMiddleware adds CORS headers for allowed origins so browser dashboards
+// can read the monitoring endpoints
+func (m *MetricsCollector) corsMiddleware(handler http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin != "" && m.corsOriginAllowed(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Add("Vary", "Origin")
+		}
+
+		// Handle preflight requests
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	}
+}
+
+// corsOriginAllowed reports whether the origin may make cross-origin requests
+func (m *MetricsCollector) corsOriginAllowed(origin string) bool {
+	for _, allowed := range m.corsOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
 // pluginListHandler returns the list of all plugins
 func (mc *MetricsCollector) pluginListHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -236,11 +718,63 @@ func (mc *MetricsCollector) pluginListHandler(w http.ResponseWriter, r *http.Req
 
 	w.Header().Set("Content-Type", "application/json")
 
-	// This would need to be injected from the plugin manager
-	// For now, return empty list
+	plugins := make([]plugin.PluginStatus, 0)
+	var discovery *plugin.DiscoveryReport
+	if pm := mc.getPluginManager(); pm != nil {
+		for _, status := range pm.ListPlugins() {
+			plugins = append(plugins, status)
+		}
+		report := pm.DiscoveryReport()
+		discovery = &report
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+
 	response := map[string]interface{}{
-		"plugins": []map[string]interface{}{},
-		"count":   0,
+		"plugins":   plugins,
+		"count":     len(plugins),
+		"discovery": discovery,
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+// toolInfo describes a registered tool on the /tools endpoint
+type toolInfo struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Version     string                 `json:"version"`
+	InputSchema map[string]interface{} `json:"input_schema"`
+}
+
+// toolListHandler returns every registered tool with its input schema
+func (mc *MetricsCollector) toolListHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	tools := make([]toolInfo, 0)
+	if registry := mc.getToolRegistry(); registry != nil {
+		for _, tool := range registry.ListTools() {
+			tools = append(tools, toolInfo{
+				Name:        tool.Name(),
+				Description: tool.Description(),
+				Version:     tool.Version(),
+				InputSchema: tool.InputSchema(),
+			})
+		}
+	}
+	sort.Slice(tools, func(i, j int) bool {
+		return tools[i].Name < tools[j].Name
+	})
+
+	response := map[string]interface{}{
+		"tools": tools,
+		"count": len(tools),
 	}
 
 	json.NewEncoder(w).Encode(response)
@@ -262,7 +796,13 @@ func (mc *MetricsCollector) pluginDetailHandler(w http.ResponseWriter, r *http.R
 
 	w.Header().Set("Content-Type", "application/json")
 
-	// This would need plugin manager integration
+	if pm := mc.getPluginManager(); pm != nil {
+		if status, exists := pm.ListPlugins()[path]; exists {
+			json.NewEncoder(w).Encode(status)
+			return
+		}
+	}
+
 	response := map[string]interface{}{
 		"error": "Plugin not found: " + path,
 	}
@@ -0,0 +1,202 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eadydb/zephyr/pkg/plugin"
+)
+
+// ToolCall is a single tool invocation as it passes through the middleware chain
+type ToolCall struct {
+	Tool      plugin.MCPToolPlugin
+	Name      string
+	RequestID string
+	Arguments map[string]interface{}
+}
+
+// ToolHandler executes a tool call, returning the raw tool result
+type ToolHandler func(ctx context.Context, call *ToolCall) (interface{}, error)
+
+// ToolMiddleware wraps a ToolHandler with a cross-cutting concern such as
+// metrics, rate limiting, or caching
+type ToolMiddleware func(next ToolHandler) ToolHandler
+
+// Chain wraps handler in the middleware; the first middleware is the outermost
+func Chain(handler ToolHandler, middleware ...ToolMiddleware) ToolHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
+
+// DefaultMiddleware is the order of the built-in middleware when none is configured
+var DefaultMiddleware = []string{"metrics", "audit", "rate_limit", "cache", "memory_budget"}
+
+// builtinMiddleware returns the named built-in middleware bound to the server
+func (s *Server) builtinMiddleware(name string) (ToolMiddleware, bool) {
+	switch name {
+	case "metrics":
+		return s.metricsMiddleware, true
+	case "audit":
+		return s.auditMiddleware, true
+	case "rate_limit":
+		return s.rateLimitMiddleware, true
+	case "cache":
+		return s.cacheMiddleware, true
+	case "memory_budget":
+		return s.memoryBudgetMiddleware, true
+	default:
+		return nil, false
+	}
+}
+
+// SetMiddleware selects and orders the built-in middleware by name. Leaving
+// one out disables it. It must be called before Start.
+func (s *Server) SetMiddleware(names []string) error {
+	middleware := make([]ToolMiddleware, 0, len(names))
+	for _, name := range names {
+		mw, ok := s.builtinMiddleware(name)
+		if !ok {
+			return fmt.Errorf("unknown tool middleware: %s (must be one of: %s)", name, strings.Join(DefaultMiddleware, ", "))
+		}
+		middleware = append(middleware, mw)
+	}
+	s.middleware = middleware
+	s.middlewareNames = append([]string(nil), names...)
+	return nil
+}
+
+// Use appends custom middleware inside the built-in chain, closest to the tool.
+// It must be called before Start.
+func (s *Server) Use(middleware ...ToolMiddleware) {
+	s.custom = append(s.custom, middleware...)
+}
+
+// buildChain assembles the handler every tool call runs through
+func (s *Server) buildChain() ToolHandler {
+	if s.middleware == nil {
+		// Unconfigured servers keep the default order
+		s.SetMiddleware(DefaultMiddleware)
+	}
+
+	middleware := append(append([]ToolMiddleware{}, s.middleware...), s.custom...)
+	return Chain(s.executeCall, middleware...)
+}
+
+// resourceMiddleware names the built-in middleware that also applies to plugin
+// resource reads; the rest only make sense for tool results
+var resourceMiddleware = map[string]bool{"audit": true, "rate_limit": true}
+
+// buildResourceChain assembles the handler plugin resource reads run through,
+// keeping the configured order of the middleware that applies to them. It
+// must run after buildChain.
+func (s *Server) buildResourceChain() ToolHandler {
+	var middleware []ToolMiddleware
+	for _, name := range s.middlewareNames {
+		if mw, ok := s.builtinMiddleware(name); ok && resourceMiddleware[name] {
+			middleware = append(middleware, mw)
+		}
+	}
+	return Chain(readResourceCall, middleware...)
+}
+
+// executeCall is the innermost handler: it runs the tool, unless it was asked
+// for a dry run it cannot honor
+func (s *Server) executeCall(ctx context.Context, call *ToolCall) (interface{}, error) {
+	if plugin.IsDryRun(call.Arguments) && !supportsDryRun(call.Tool) {
+		return nil, fmt.Errorf("%w by tool %s", ErrDryRunNotSupported, call.Name)
+	}
+
+	result, err := executeWithRecovery(ctx, call.Tool, call.Arguments, call.RequestID)
+	if typed, ok := result.(*plugin.ToolResult); ok && err == nil {
+		err = typed.Err()
+	}
+	return result, err
+}
+
+// metricsMiddleware records the duration and outcome of every call
+func (s *Server) metricsMiddleware(next ToolHandler) ToolHandler {
+	return func(ctx context.Context, call *ToolCall) (interface{}, error) {
+		startTime := time.Now()
+		result, err := next(ctx, call)
+		if s.metrics != nil {
+			s.metrics.RecordRequest(time.Since(startTime), call.Name, err != nil)
+		}
+		return result, err
+	}
+}
+
+// auditMiddleware records every call in the audit trail
+func (s *Server) auditMiddleware(next ToolHandler) ToolHandler {
+	return func(ctx context.Context, call *ToolCall) (interface{}, error) {
+		startTime := time.Now()
+		result, err := next(ctx, call)
+
+		entry := AuditEntry{
+			Timestamp: startTime,
+			RequestID: call.RequestID,
+			ClientIP:  plugin.ClientIPFromContext(ctx),
+			Tool:      call.Name,
+			Arguments: s.redactor.Redact(call.Arguments),
+			Duration:  time.Since(startTime),
+			Success:   err == nil,
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		s.auditSink.Record(entry)
+
+		return result, err
+	}
+}
+
+// rateLimitMiddleware rejects calls over the global or per-tool rate limit
+func (s *Server) rateLimitMiddleware(next ToolHandler) ToolHandler {
+	return func(ctx context.Context, call *ToolCall) (interface{}, error) {
+		if !s.limiter.allow(call.Name) {
+			if s.metrics != nil {
+				s.metrics.RecordThrottled(call.Name)
+			}
+			return nil, fmt.Errorf("%w for tool %s", ErrRateLimited, call.Name)
+		}
+		return next(ctx, call)
+	}
+}
+
+// cacheMiddleware serves repeated calls to cached tools from the response cache
+func (s *Server) cacheMiddleware(next ToolHandler) ToolHandler {
+	return func(ctx context.Context, call *ToolCall) (interface{}, error) {
+		if !s.cache.cacheable(call.Name, call.Arguments) {
+			return next(ctx, call)
+		}
+
+		result, cached := s.cache.get(call.Name, call.Arguments)
+		if s.metrics != nil {
+			s.metrics.RecordCacheLookup(call.Name, cached)
+		}
+		if cached {
+			return result, nil
+		}
+
+		result, err := next(ctx, call)
+		if err == nil {
+			s.cache.put(call.Name, call.Arguments, result)
+		}
+		return result, err
+	}
+}
+
+// memoryBudgetMiddleware checks the allocations of a call against the per-call budget
+func (s *Server) memoryBudgetMiddleware(next ToolHandler) ToolHandler {
+	return func(ctx context.Context, call *ToolCall) (interface{}, error) {
+		sample := s.memory.start()
+		result, err := next(ctx, call)
+		if memErr := s.checkMemoryBudget(sample, call.Name, call.RequestID); memErr != nil && err == nil {
+			return nil, memErr
+		}
+		return result, err
+	}
+}
@@ -0,0 +1,203 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+
+	"github.com/eadydb/zephyr/pkg/plugin"
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// pluginResources tracks the resources registered from plugins
+type pluginResources struct {
+	byTool map[string][]string // tool -> URIs and URI templates it registered
+	owners map[string]string   // URI or URI template -> tool
+
+	// providers serves the templates: the MCP library cannot remove a template,
+	// so a template outlives an unloaded plugin and reads fail until it returns
+	providers map[string]plugin.MCPToolPlugin
+}
+
+// resourceURIArg is the argument that carries the URI of a resource read
+// through the middleware, as it appears in the audit trail
+const resourceURIArg = "resource_uri"
+
+// newPluginResources creates an empty set of plugin resources
+func newPluginResources() *pluginResources {
+	return &pluginResources{
+		byTool:    make(map[string][]string),
+		owners:    make(map[string]string),
+		providers: make(map[string]plugin.MCPToolPlugin),
+	}
+}
+
+// registerResources registers the resources of a tool whose plugin provides any
+func (s *Server) registerResources(tool plugin.MCPToolPlugin) {
+	provider, ok := tool.(plugin.ResourceProvider)
+	if !ok {
+		return
+	}
+	resources := provider.Resources()
+	if len(resources) == 0 {
+		return
+	}
+
+	s.pluginResourcesMu.Lock()
+	defer s.pluginResourcesMu.Unlock()
+
+	var registered []string
+	for _, resource := range resources {
+		key := resource.URI
+		if resource.URITemplate != "" {
+			key = resource.URITemplate
+		}
+		if key == "" {
+			slog.Warn("Skipping resource without a URI", "tool", tool.Name(), "resource", resource.Name)
+			continue
+		}
+		if owner, taken := s.pluginResources.owners[key]; taken && owner != tool.Name() {
+			slog.Warn("Skipping resource with a URI already in use", "uri", key, "tool", tool.Name(), "owner", owner)
+			continue
+		}
+
+		if resource.URITemplate != "" {
+			if err := s.addResourceTemplate(resource); err != nil {
+				slog.Warn("Skipping resource with an invalid URI template", "uri", key, "tool", tool.Name(), "error", err)
+				continue
+			}
+			s.pluginResources.providers[key] = tool
+		} else {
+			s.mcpServer.AddResource(
+				mcp.NewResource(resource.URI, resource.Name,
+					mcp.WithResourceDescription(resource.Description),
+					mcp.WithMIMEType(resource.MIMEType)),
+				s.resourceHandler(tool),
+			)
+		}
+
+		s.pluginResources.owners[key] = tool.Name()
+		registered = append(registered, key)
+	}
+	if len(registered) == 0 {
+		return
+	}
+
+	s.pluginResources.byTool[tool.Name()] = registered
+	slog.Info("Resource list changed", "tool", tool.Name(), "added", registered)
+}
+
+// addResourceTemplate registers a template whose reads go to its current provider
+func (s *Server) addResourceTemplate(resource plugin.Resource) (err error) {
+	// The MCP library panics on a malformed template
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	key := resource.URITemplate
+	template := mcp.NewResourceTemplate(key, resource.Name,
+		mcp.WithTemplateDescription(resource.Description),
+		mcp.WithTemplateMIMEType(resource.MIMEType))
+
+	s.mcpServer.AddResourceTemplate(template, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		s.pluginResourcesMu.Lock()
+		tool, exists := s.pluginResources.providers[key]
+		s.pluginResourcesMu.Unlock()
+		if !exists {
+			return nil, fmt.Errorf("resource %s is unavailable: its plugin is not loaded", request.Params.URI)
+		}
+		return s.resourceHandler(tool)(ctx, request)
+	})
+	return nil
+}
+
+// unregisterResources removes the resources registered for a tool
+func (s *Server) unregisterResources(toolName string) {
+	s.pluginResourcesMu.Lock()
+	defer s.pluginResourcesMu.Unlock()
+
+	keys := s.pluginResources.byTool[toolName]
+	if len(keys) == 0 {
+		return
+	}
+	for _, key := range keys {
+		delete(s.pluginResources.owners, key)
+		if _, isTemplate := s.pluginResources.providers[key]; isTemplate {
+			delete(s.pluginResources.providers, key)
+			continue
+		}
+		s.mcpServer.RemoveResource(key)
+	}
+	delete(s.pluginResources.byTool, toolName)
+
+	slog.Info("Resource list changed", "tool", toolName, "removed", keys)
+}
+
+// readResourceCall is the innermost handler of a resource read, converting a
+// panic in the plugin into an ErrToolPanic error
+func readResourceCall(ctx context.Context, call *ToolCall) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("%w: %v", ErrToolPanic, r)
+		}
+	}()
+
+	provider, ok := call.Tool.(plugin.ResourceProvider)
+	if !ok {
+		return nil, fmt.Errorf("tool %s does not provide resources", call.Name)
+	}
+	uri, _ := call.Arguments[resourceURIArg].(string)
+	return provider.ReadResource(ctx, uri)
+}
+
+// resourceHandler reads a plugin resource for a resources/read request. Reads
+// are audited and rate limited as calls to the tool that owns the resource.
+func (s *Server) resourceHandler(tool plugin.MCPToolPlugin) server.ResourceHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		requestID := plugin.RequestIDFromContext(ctx)
+		if requestID == "" {
+			requestID = uuid.NewString()
+			ctx = plugin.WithRequestID(ctx, requestID)
+		}
+
+		call := &ToolCall{
+			Tool:      tool,
+			Name:      tool.Name(),
+			RequestID: requestID,
+			Arguments: map[string]interface{}{resourceURIArg: request.Params.URI},
+		}
+		result, err := s.resourceChain(ctx, call)
+		if err != nil {
+			slog.Error("Resource read failed",
+				"tool", call.Name,
+				"request_id", requestID,
+				"uri", request.Params.URI,
+				"error", err)
+			return nil, fmt.Errorf("failed to read resource %s (request_id: %s): %w", request.Params.URI, requestID, err)
+		}
+		contents, _ := result.([]plugin.ResourceContent)
+
+		resourceContents := make([]mcp.ResourceContents, 0, len(contents))
+		for _, content := range contents {
+			uri := content.URI
+			if uri == "" {
+				uri = request.Params.URI
+			}
+			if content.Blob != nil {
+				resourceContents = append(resourceContents, mcp.BlobResourceContents{
+					URI:      uri,
+					MIMEType: content.MIMEType,
+					Blob:     base64.StdEncoding.EncodeToString(content.Blob),
+				})
+				continue
+			}
+			resourceContents = append(resourceContents, mcp.TextResourceContents{URI: uri, MIMEType: content.MIMEType, Text: content.Text})
+		}
+		return resourceContents, nil
+	}
+}
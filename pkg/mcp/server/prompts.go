@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/eadydb/zephyr/pkg/plugin"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// registerPrompts registers the prompts of a tool whose plugin provides any.
+// Prompts are only served when the prompts capability is enabled.
+func (s *Server) registerPrompts(tool plugin.MCPToolPlugin) {
+	provider, ok := tool.(plugin.PromptProvider)
+	if !ok {
+		return
+	}
+	prompts := provider.Prompts()
+	if len(prompts) == 0 {
+		return
+	}
+	if !s.options.Prompts {
+		slog.Debug("Ignoring plugin prompts, the prompts capability is disabled", "tool", tool.Name())
+		return
+	}
+
+	s.promptsMu.Lock()
+	defer s.promptsMu.Unlock()
+
+	var entries []server.ServerPrompt
+	var names []string
+	for _, prompt := range prompts {
+		if owner, taken := s.promptOwners[prompt.Name]; taken && owner != tool.Name() {
+			slog.Warn("Skipping prompt with a name already in use", "prompt", prompt.Name, "tool", tool.Name(), "owner", owner)
+			continue
+		}
+		s.promptOwners[prompt.Name] = tool.Name()
+		names = append(names, prompt.Name)
+		entries = append(entries, server.ServerPrompt{
+			Prompt:  mcpPrompt(prompt),
+			Handler: promptHandler(provider, prompt),
+		})
+	}
+	if len(entries) == 0 {
+		return
+	}
+
+	s.toolPrompts[tool.Name()] = names
+	s.mcpServer.AddPrompts(entries...)
+	slog.Info("Prompt list changed", "tool", tool.Name(), "added", names)
+}
+
+// unregisterPrompts removes the prompts registered for a tool
+func (s *Server) unregisterPrompts(toolName string) {
+	s.promptsMu.Lock()
+	defer s.promptsMu.Unlock()
+
+	names := s.toolPrompts[toolName]
+	if len(names) == 0 {
+		return
+	}
+	for _, name := range names {
+		delete(s.promptOwners, name)
+	}
+	delete(s.toolPrompts, toolName)
+
+	s.mcpServer.DeletePrompts(names...)
+	slog.Info("Prompt list changed", "tool", toolName, "removed", names)
+}
+
+// mcpPrompt converts a plugin prompt to its MCP definition
+func mcpPrompt(prompt plugin.Prompt) mcp.Prompt {
+	opts := []mcp.PromptOption{mcp.WithPromptDescription(prompt.Description)}
+	for _, arg := range prompt.Arguments {
+		argOpts := []mcp.ArgumentOption{mcp.ArgumentDescription(arg.Description)}
+		if arg.Required {
+			argOpts = append(argOpts, mcp.RequiredArgument())
+		}
+		opts = append(opts, mcp.WithArgument(arg.Name, argOpts...))
+	}
+	return mcp.NewPrompt(prompt.Name, opts...)
+}
+
+// promptHandler renders a plugin prompt for a prompts/get request
+func promptHandler(provider plugin.PromptProvider, prompt plugin.Prompt) server.PromptHandlerFunc {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args := request.Params.Arguments
+		for _, arg := range prompt.Arguments {
+			if _, present := args[arg.Name]; arg.Required && !present {
+				return nil, fmt.Errorf("%w: prompt %s requires argument %s", plugin.ErrInvalidArguments, prompt.Name, arg.Name)
+			}
+		}
+
+		messages, err := provider.GetPrompt(ctx, prompt.Name, args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render prompt %s: %w", prompt.Name, err)
+		}
+
+		result := make([]mcp.PromptMessage, 0, len(messages))
+		for _, message := range messages {
+			role := mcp.Role(message.Role)
+			if role != mcp.RoleAssistant {
+				role = mcp.RoleUser
+			}
+			result = append(result, mcp.NewPromptMessage(role, mcp.NewTextContent(message.Text)))
+		}
+		return mcp.NewGetPromptResult(prompt.Description, result), nil
+	}
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// tokenBucket allows up to perMinute calls per minute with bursts of the same size
+type tokenBucket struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	rate     float64 // tokens per second
+	last     time.Time
+}
+
+// newTokenBucket creates a full bucket refilling at perMinute tokens per minute
+func newTokenBucket(perMinute int) *tokenBucket {
+	return &tokenBucket{
+		capacity: float64(perMinute),
+		tokens:   float64(perMinute),
+		rate:     float64(perMinute) / 60,
+		last:     time.Now(),
+	}
+}
+
+// allow takes a token if one is available
+func (b *tokenBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// rateLimiter applies per-tool limits, falling back to a global limit shared by
+// all tools without their own
+type rateLimiter struct {
+	global  *tokenBucket            // nil when there is no global limit
+	perTool map[string]*tokenBucket // tools with a dedicated limit
+}
+
+// newRateLimiter creates a limiter from requests-per-minute settings. Non-positive values mean unlimited.
+func newRateLimiter(globalPerMinute int, perToolPerMinute map[string]int) *rateLimiter {
+	limiter := &rateLimiter{perTool: make(map[string]*tokenBucket)}
+	if globalPerMinute > 0 {
+		limiter.global = newTokenBucket(globalPerMinute)
+	}
+	for tool, perMinute := range perToolPerMinute {
+		if perMinute > 0 {
+			limiter.perTool[tool] = newTokenBucket(perMinute)
+		}
+	}
+	return limiter
+}
+
+// allow reports whether a call to the tool may proceed
+func (l *rateLimiter) allow(toolName string) bool {
+	if l == nil {
+		return true
+	}
+	if bucket, exists := l.perTool[toolName]; exists {
+		return bucket.allow()
+	}
+	if l.global != nil {
+		return l.global.allow()
+	}
+	return true
+}
+
+// SetRateLimits sets the requests-per-minute limits for tool calls. Tools listed in
+// perTool use their own limit; all others share the global one. A non-positive
+// limit means unlimited. It must be called before Start.
+func (s *Server) SetRateLimits(global int, perTool map[string]int) {
+	s.limiter = newRateLimiter(global, perTool)
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/eadydb/zephyr/pkg/redact"
+)
+
+// RedactedValue replaces the value of every argument whose key matches a redaction pattern
+const RedactedValue = "***"
+
+// DefaultRedactPatterns are the argument key patterns redacted when none are configured
+var DefaultRedactPatterns = redact.DefaultPatterns
+
+// Redactor masks sensitive tool arguments before they are logged or recorded.
+// Patterns are case-insensitive regular expressions matched anywhere in an
+// argument key unless anchored with ^ or $.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor compiles the given key patterns into a redactor
+func NewRedactor(patterns []string) (*Redactor, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+
+	return &Redactor{patterns: compiled}, nil
+}
+
+// DefaultRedactor returns a redactor using DefaultRedactPatterns
+func DefaultRedactor() *Redactor {
+	redactor, err := NewRedactor(DefaultRedactPatterns)
+	if err != nil {
+		panic(err) // the defaults are constant and known to compile
+	}
+	return redactor
+}
+
+// Redact returns a copy of args with sensitive values replaced, descending into
+// nested objects and arrays. The input map is never modified.
+func (r *Redactor) Redact(args map[string]interface{}) map[string]interface{} {
+	if args == nil {
+		return nil
+	}
+
+	redacted := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		if r.matches(key) {
+			redacted[key] = RedactedValue
+			continue
+		}
+		redacted[key] = r.redactValue(value)
+	}
+
+	return redacted
+}
+
+// redactValue redacts nested structures within an argument value
+func (r *Redactor) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return r.Redact(v)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = r.redactValue(item)
+		}
+		return items
+	default:
+		return v
+	}
+}
+
+// matches reports whether the key matches any redaction pattern
+func (r *Redactor) matches(key string) bool {
+	for _, re := range r.patterns {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package server
+
+import "testing"
+
+func TestDefaultRedactorKeys(t *testing.T) {
+	redactor := DefaultRedactor()
+
+	masked := []string{
+		"password", "db_password", "secret", "secret_key", "secret_access_key",
+		"aws_secret_access_key", "clientSecret", "token", "token_value",
+		"access_token", "accessToken", "auth_token_id", "api_key", "apiKey", "x-api-key",
+	}
+	for _, key := range masked {
+		got := redactor.Redact(map[string]interface{}{key: "value"})
+		if got[key] != RedactedValue {
+			t.Errorf("%s = %v, want it redacted", key, got[key])
+		}
+	}
+
+	kept := []string{"max_tokens", "tokens", "path", "operation"}
+	for _, key := range kept {
+		got := redactor.Redact(map[string]interface{}{key: "value"})
+		if got[key] != "value" {
+			t.Errorf("%s = %v, want it kept", key, got[key])
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/eadydb/zephyr/pkg/plugin"
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// resultResourcePrefix is the URI scheme and path of tool results published as resources
+const resultResourcePrefix = "zephyr://results/"
+
+// defaultResultResourceTTL is how long a published result stays readable when no TTL is configured
+const defaultResultResourceTTL = 10 * time.Minute
+
+// resultResources publishes large tool results as MCP resources that expire after a TTL
+type resultResources struct {
+	mu        sync.Mutex
+	threshold int                  // results larger than this many bytes are published; 0 disables
+	ttl       time.Duration        // lifetime of a published result
+	expiries  map[string]time.Time // uri -> expiry
+}
+
+// SetResultResources makes the server return results larger than threshold bytes
+// as a reference to an MCP resource instead of inline text. Published results
+// can be read until ttl elapses. A zero threshold disables size-based publishing;
+// plugins can still return a plugin.ResourceResult explicitly. It must be called before Start.
+func (s *Server) SetResultResources(threshold int, ttl time.Duration) {
+	s.resources = newResultResources(threshold, ttl)
+}
+
+// newResultResources creates the store of published results
+func newResultResources(threshold int, ttl time.Duration) *resultResources {
+	if ttl <= 0 {
+		ttl = defaultResultResourceTTL
+	}
+	return &resultResources{
+		threshold: threshold,
+		ttl:       ttl,
+		expiries:  make(map[string]time.Time),
+	}
+}
+
+// resultContent returns the tool result as MCP content, publishing it as a resource
+// when the plugin asked for it or it exceeds the size threshold
+func (s *Server) resultContent(toolName string, result interface{}, pretty bool) mcp.Content {
+	if resource, ok := result.(plugin.ResourceResult); ok {
+		return s.publishResult(toolName, resource)
+	}
+
+	text := formatResult(result, pretty)
+	if s.resources.threshold > 0 && len(text) > s.resources.threshold {
+		mimeType := "text/plain"
+		if json.Valid([]byte(text)) {
+			mimeType = "application/json"
+		}
+		return s.publishResult(toolName, plugin.ResourceResult{Text: text, MIMEType: mimeType})
+	}
+
+	return mcp.NewTextContent(text)
+}
+
+// publishResult registers the result as a resource and returns a reference to it
+func (s *Server) publishResult(toolName string, resource plugin.ResourceResult) mcp.Content {
+	s.resources.sweep(s)
+
+	uri := resultResourcePrefix + uuid.NewString()
+	name := resource.Name
+	if name == "" {
+		name = fmt.Sprintf("%s result", toolName)
+	}
+	mimeType := resource.MIMEType
+	if mimeType == "" {
+		mimeType = "text/plain"
+	}
+	expiresAt := time.Now().Add(s.resources.ttl)
+
+	text := resource.Text
+	s.mcpServer.AddResource(
+		mcp.NewResource(uri, name, mcp.WithMIMEType(mimeType)),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{URI: uri, MIMEType: mimeType, Text: text},
+			}, nil
+		},
+	)
+
+	s.resources.mu.Lock()
+	s.resources.expiries[uri] = expiresAt
+	s.resources.mu.Unlock()
+
+	slog.Debug("Published tool result as resource", "tool", toolName, "uri", uri, "size", len(text))
+
+	reference, _ := json.Marshal(map[string]interface{}{
+		"resource_uri": uri,
+		"mime_type":    mimeType,
+		"size_bytes":   len(text),
+		"expires_at":   expiresAt.Format(time.RFC3339),
+	})
+	return mcp.NewTextContent(string(reference))
+}
+
+// sweep removes published results whose TTL has elapsed
+func (r *resultResources) sweep(s *Server) {
+	r.mu.Lock()
+	now := time.Now()
+	var expired []string
+	for uri, expiresAt := range r.expiries {
+		if now.After(expiresAt) {
+			expired = append(expired, uri)
+			delete(r.expiries, uri)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, uri := range expired {
+		s.mcpServer.RemoveResource(uri)
+	}
+}
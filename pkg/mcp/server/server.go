@@ -1,44 +1,151 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"log/slog"
-	"time"
+	"sort"
+	"sync"
 
 	"github.com/eadydb/zephyr/pkg/plugin"
+	"github.com/google/uuid"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
 // Server wraps the MCP server with tool registry
 type Server struct {
-	mcpServer *server.MCPServer
-	registry  plugin.ToolRegistry
-	metrics   *MetricsCollector
-	name      string
-	version   string
+	mcpServer  *server.MCPServer
+	registry   plugin.ToolRegistry
+	metrics    *MetricsCollector
+	auditSink  AuditSink
+	redactor   *Redactor
+	pretty     bool // pretty-print JSON results by default
+	memory     memoryBudget
+	limiter    *rateLimiter     // nil means unlimited
+	cache      *responseCache   // nil disables response caching
+	structured map[string]bool  // tools whose JSON results are also returned parsed
+	middleware []ToolMiddleware // built-in middleware, outermost first
+	custom     []ToolMiddleware // middleware added with Use
+	chain      ToolHandler      // assembled by Start
+	resources  *resultResources
+	options    ServerOptions
+	name       string
+	version    string
+
+	middlewareNames []string    // names of the built-in middleware, in order
+	resourceChain   ToolHandler // plugin resource reads, assembled by Start
+
+	// Prompts registered from plugins, by tool and by prompt name
+	promptsMu    sync.Mutex
+	toolPrompts  map[string][]string
+	promptOwners map[string]string
+
+	// Resources registered from plugins
+	pluginResourcesMu sync.Mutex
+	pluginResources   *pluginResources
+}
+
+// ServerOptions configures a new server and the MCP capabilities it advertises
+// beyond tools. Tools and resources are always enabled; resources carry
+// oversized results.
+type ServerOptions struct {
+	// Metrics records tool calls; a new collector is created when nil
+	Metrics *MetricsCollector
+
+	// Prompts advertises the prompts capability and serves the prompts of
+	// plugins implementing plugin.PromptProvider
+	Prompts bool
+
+	// Logging advertises the logging capability so clients may set a log level
+	Logging bool
+
+	// Instructions tells clients how to use the server, sent when they initialize
+	Instructions string
 }
 
 // New creates a new MCP server instance
 func New(name, version string, registry plugin.ToolRegistry) *Server {
-	return &Server{
-		name:     name,
-		version:  version,
-		registry: registry,
-		metrics:  NewMetricsCollector(), // Create default metrics collector
-	}
+	return NewWithOptions(name, version, registry, nil)
 }
 
 // NewWithMetrics creates a new MCP server instance with custom metrics collector
 func NewWithMetrics(name, version string, registry plugin.ToolRegistry, metrics *MetricsCollector) *Server {
+	return NewWithOptions(name, version, registry, &ServerOptions{Metrics: metrics})
+}
+
+// NewWithOptions creates a new MCP server instance with custom options
+func NewWithOptions(name, version string, registry plugin.ToolRegistry, opts *ServerOptions) *Server {
+	if opts == nil {
+		opts = &ServerOptions{}
+	}
+
+	metrics := opts.Metrics
+	if metrics == nil {
+		metrics = NewMetricsCollector()
+	}
+
 	return &Server{
-		name:     name,
-		version:  version,
-		registry: registry,
-		metrics:  metrics,
+		name:      name,
+		version:   version,
+		registry:  registry,
+		metrics:   metrics,
+		auditSink: NopAuditSink{},
+		redactor:  DefaultRedactor(),
+		resources: newResultResources(0, 0),
+		options:   *opts,
+
+		toolPrompts:  make(map[string][]string),
+		promptOwners: make(map[string]string),
+
+		pluginResources: newPluginResources(),
+	}
+}
+
+// serverOptions maps the configured options to MCP server options
+func (s *Server) serverOptions() []server.ServerOption {
+	// listChanged lets clients refresh when plugins come and go
+	opts := []server.ServerOption{
+		server.WithToolCapabilities(true),
+		server.WithResourceCapabilities(false, true),
+	}
+	if s.options.Prompts {
+		opts = append(opts, server.WithPromptCapabilities(true))
+	}
+	if s.options.Logging {
+		opts = append(opts, server.WithLogging())
+	}
+	if s.options.Instructions != "" {
+		opts = append(opts, server.WithInstructions(s.options.Instructions))
+	}
+	return opts
+}
+
+// SetRedactor sets the redactor applied to tool arguments before they are
+// logged or recorded. It must be called before Start.
+func (s *Server) SetRedactor(redactor *Redactor) {
+	if redactor == nil {
+		redactor = DefaultRedactor()
 	}
+	s.redactor = redactor
+}
+
+// SetPrettyJSON sets whether JSON tool results are indented by default.
+// Clients can override it per call with the _pretty meta-argument.
+func (s *Server) SetPrettyJSON(pretty bool) {
+	s.pretty = pretty
+}
+
+// SetAuditSink sets the sink that receives a record of every tool call.
+// It must be called before Start.
+func (s *Server) SetAuditSink(sink AuditSink) {
+	if sink == nil {
+		sink = NopAuditSink{}
+	}
+	s.auditSink = sink
 }
 
 // Start starts the MCP server
@@ -46,7 +153,14 @@ func (s *Server) Start() error {
 	slog.Info("Starting MCP server", "name", s.name, "version", s.version)
 
 	// Create new MCP server
-	s.mcpServer = server.NewMCPServer(s.name, s.version)
+	s.mcpServer = server.NewMCPServer(s.name, s.version, s.serverOptions()...)
+
+	s.chain = s.buildChain()
+	s.resourceChain = s.buildResourceChain()
+
+	// Keep the MCP tool list in sync with the registry. AddTool and DeleteTools
+	// emit notifications/tools/list_changed to all connected clients.
+	s.subscribeToRegistry()
 
 	// Register tools with MCP server
 	if err := s.registerTools(); err != nil {
@@ -82,6 +196,186 @@ func (s *Server) GetMetrics() *MetricsCollector {
 	return s.metrics
 }
 
+// subscribeToRegistry mirrors registry changes into the MCP server's tool list
+func (s *Server) subscribeToRegistry() {
+	if s.registry == nil {
+		return
+	}
+
+	s.registry.OnRegister(func(tool plugin.MCPToolPlugin) {
+		if err := s.registerTool(tool); err != nil {
+			slog.Warn("Failed to register tool", "name", tool.Name(), "error", err)
+			return
+		}
+		slog.Info("Tool list changed", "added", tool.Name())
+		s.registerPrompts(tool)
+		s.registerResources(tool)
+	})
+
+	s.registry.OnUnregister(func(name string) {
+		s.mcpServer.DeleteTools(name)
+		slog.Info("Tool list changed", "removed", name)
+		s.unregisterPrompts(name)
+		s.unregisterResources(name)
+	})
+}
+
+// formatResult renders a tool result as text. JSON output is compact unless
+// pretty is set, in which case it is indented for human readers.
+func formatResult(result interface{}, pretty bool) string {
+	switch v := result.(type) {
+	case string:
+		// Plugins commonly return pre-encoded JSON strings
+		if pretty && json.Valid([]byte(v)) {
+			var indented bytes.Buffer
+			if err := json.Indent(&indented, []byte(v), "", "  "); err == nil {
+				return indented.String()
+			}
+		}
+		return v
+	case map[string]interface{}, []interface{}:
+		// For complex data, format as JSON
+		var jsonBytes []byte
+		var err error
+		if pretty {
+			jsonBytes, err = json.MarshalIndent(v, "", "  ")
+		} else {
+			jsonBytes, err = json.Marshal(v)
+		}
+		if err != nil {
+			return fmt.Sprintf("%+v", v)
+		}
+		return string(jsonBytes)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// toolCallResult converts a successful tool result into MCP content. Typed
+// results keep their content items; other values are formatted as a single text item.
+func (s *Server) toolCallResult(toolName string, result interface{}, pretty bool) *mcp.CallToolResult {
+	typed, ok := result.(*plugin.ToolResult)
+	if !ok {
+		return s.withStructuredContent(toolName, result, &mcp.CallToolResult{
+			Content: []mcp.Content{s.resultContent(toolName, result, pretty)},
+		})
+	}
+
+	content := make([]mcp.Content, 0, len(typed.Content))
+	for _, item := range typed.Content {
+		switch item.Type {
+		case plugin.ContentJSON:
+			data, err := json.Marshal(item.Data)
+			if err != nil {
+				content = append(content, mcp.NewTextContent(fmt.Sprintf("%+v", item.Data)))
+				continue
+			}
+			content = append(content, s.resultContent(toolName, string(data), pretty))
+		case plugin.ContentImage:
+			content = append(content, mcp.NewImageContent(base64.StdEncoding.EncodeToString(item.Blob), item.MIMEType))
+		default:
+			content = append(content, s.resultContent(toolName, item.Text, false))
+		}
+	}
+
+	return s.withStructuredContent(toolName, result, &mcp.CallToolResult{Content: content})
+}
+
+// supportsDryRun reports whether a tool opted in to dry-run calls
+func supportsDryRun(tool plugin.MCPToolPlugin) bool {
+	runner, ok := tool.(plugin.DryRunner)
+	return ok && runner.SupportsDryRun()
+}
+
+// withoutArg returns a copy of args without the given key
+func withoutArg(args map[string]interface{}, key string) map[string]interface{} {
+	filtered := make(map[string]interface{}, len(args))
+	for k, v := range args {
+		if k != key {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
+
+// deprecationNotice describes a deprecated tool and its replacement, if any
+func deprecationNotice(def plugin.MCPTool) string {
+	if def.ReplacedBy != "" {
+		return fmt.Sprintf("[DEPRECATED: use %s instead]", def.ReplacedBy)
+	}
+	return "[DEPRECATED]"
+}
+
+// requiredFields collects the required argument names of a tool schema. The
+// top-level list may be []string from Go literals, []interface{} after JSON
+// decoding, or a single string; properties may also mark themselves with the
+// older "required": true form, which is moved into the list. Names are
+// deduplicated in order of appearance.
+func requiredFields(required interface{}, props map[string]interface{}) (map[string]interface{}, []string) {
+	var names []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	switch list := required.(type) {
+	case []string:
+		for _, name := range list {
+			add(name)
+		}
+	case []interface{}:
+		for _, v := range list {
+			if name, ok := v.(string); ok {
+				add(name)
+			}
+		}
+	case string:
+		add(list)
+	}
+
+	// Property-level flags are not valid in current JSON Schema, so strip them
+	// from a copy rather than passing them on to clients
+	var cleaned map[string]interface{}
+	for _, name := range sortedKeys(props) {
+		prop, ok := props[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		flag, ok := prop["required"].(bool)
+		if !ok {
+			continue
+		}
+		if cleaned == nil {
+			cleaned = make(map[string]interface{}, len(props))
+			for k, v := range props {
+				cleaned[k] = v
+			}
+		}
+		cleaned[name] = withoutArg(prop, "required")
+		if flag {
+			add(name)
+		}
+	}
+	if cleaned != nil {
+		props = cleaned
+	}
+
+	return props, names
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // registerTools registers all tools from the registry with the MCP server
 func (s *Server) registerTools() error {
 	if s.registry == nil {
@@ -105,6 +399,8 @@ func (s *Server) registerTools() error {
 			continue
 		}
 		toolNames = append(toolNames, tool.Name())
+		s.registerPrompts(tool)
+		s.registerResources(tool)
 	}
 
 	slog.Info("Registered tools", "count", len(toolNames), "tools", toolNames)
@@ -115,53 +411,59 @@ func (s *Server) registerTools() error {
 func (s *Server) registerTool(tool plugin.MCPToolPlugin) error {
 	toolDef := tool.MCPToolDefinition()
 
-	// Create MCP tool handler with metrics instrumentation
+	// Defaults declared in the input schema, resolved below with the properties
+	var defaults map[string]json.RawMessage
+
+	// Create the MCP tool handler; cross-cutting concerns run in the middleware chain
 	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		startTime := time.Now()
 		toolName := tool.Name()
 
+		// Tag the call with a request ID that is returned on failure and visible to the plugin
+		requestID := plugin.RequestIDFromContext(ctx)
+		if requestID == "" {
+			requestID = uuid.NewString()
+			ctx = plugin.WithRequestID(ctx, requestID)
+		}
+
+		if toolDef.Deprecated {
+			slog.Warn("Deprecated tool called",
+				"tool", toolName,
+				"request_id", requestID,
+				"replaced_by", toolDef.ReplacedBy)
+		}
+
 		// Convert arguments to map using the helper method
 		input := request.GetArguments()
 
-		// Execute the tool
-		result, err := tool.Execute(ctx, input)
-		duration := time.Since(startTime)
+		// Resolve and strip the output formatting meta-argument
+		pretty := s.pretty
+		if value, present := input[plugin.PrettyArg]; present {
+			if p, ok := value.(bool); ok {
+				pretty = p
+			}
+			input = withoutArg(input, plugin.PrettyArg)
+		}
 
-		// Record metrics
-		if s.metrics != nil {
-			s.metrics.RecordRequest(duration, toolName, err != nil)
+		// A dry-run flag set to false is not a dry run; drop it so tools never see it
+		if _, present := input[plugin.DryRunArg]; present && !plugin.IsDryRun(input) {
+			input = withoutArg(input, plugin.DryRunArg)
 		}
 
+		// Fill arguments the client left out from the schema defaults
+		input = withDefaults(input, defaults)
+
+		call := &ToolCall{Tool: tool, Name: toolName, RequestID: requestID, Arguments: input}
+		result, err := s.chain(ctx, call)
 		if err != nil {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.NewTextContent(fmt.Sprintf("Error executing tool %s: %v", toolName, err)),
-				},
-				IsError: true,
-			}, nil
-		}
-
-		// Format result as text content
-		resultText := ""
-		switch v := result.(type) {
-		case string:
-			resultText = v
-		case map[string]interface{}, []interface{}:
-			// For complex data, format as JSON
-			if jsonBytes, err := json.Marshal(v); err == nil {
-				resultText = string(jsonBytes)
-			} else {
-				resultText = fmt.Sprintf("%+v", v)
-			}
-		default:
-			resultText = fmt.Sprintf("%v", v)
+			slog.Error("Tool execution failed",
+				"tool", toolName,
+				"request_id", requestID,
+				"arguments", s.redactor.Redact(call.Arguments),
+				"error", err)
+			return errorResult(toolName, requestID, err), nil
 		}
 
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(resultText),
-			},
-		}, nil
+		return s.toolCallResult(toolName, result, pretty), nil
 	}
 
 	// Create MCP tool definition with proper schema type
@@ -174,25 +476,24 @@ func (s *Server) registerTool(tool plugin.MCPToolPlugin) error {
 		},
 	}
 
+	// MCP has no deprecation field, so flag it where clients show the tool
+	if toolDef.Deprecated {
+		notice := deprecationNotice(toolDef)
+		mcpTool.Description = notice + " " + toolDef.Description
+		mcpTool.Annotations.Title = toolDef.Name + " (deprecated)"
+	}
+
 	// If the tool has properties with required fields, extract them
 	if props, ok := toolDef.InputSchema["properties"].(map[string]interface{}); ok {
-		mcpTool.InputSchema.Properties = props
-		if required, ok := toolDef.InputSchema["required"].([]string); ok {
-			mcpTool.InputSchema.Required = required
-		} else if required, ok := toolDef.InputSchema["required"].([]interface{}); ok {
-			// Convert []interface{} to []string
-			stringRequired := make([]string, len(required))
-			for i, v := range required {
-				if s, ok := v.(string); ok {
-					stringRequired[i] = s
-				}
-			}
-			mcpTool.InputSchema.Required = stringRequired
-		}
+		mcpTool.InputSchema.Properties, mcpTool.InputSchema.Required = requiredFields(toolDef.InputSchema["required"], props)
+	} else if schemaType, _ := toolDef.InputSchema["type"].(string); schemaType == "object" {
+		// An object schema without properties can still list required fields
+		mcpTool.InputSchema.Properties, mcpTool.InputSchema.Required = requiredFields(toolDef.InputSchema["required"], map[string]interface{}{})
 	} else {
-		// If no properties, use the whole schema as properties
-		mcpTool.InputSchema.Properties = toolDef.InputSchema
+		// Otherwise the map is the properties themselves
+		mcpTool.InputSchema.Properties, mcpTool.InputSchema.Required = requiredFields(nil, toolDef.InputSchema)
 	}
+	defaults = schemaDefaults(toolDef.Name, mcpTool.InputSchema.Properties)
 
 	// Register with MCP server
 	s.mcpServer.AddTool(mcpTool, handler)
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRequiredFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		required  interface{}
+		props     map[string]interface{}
+		wantNames []string
+		wantProps map[string]interface{}
+	}{
+		{
+			name:      "string slice",
+			required:  []string{"path", "mode"},
+			props:     map[string]interface{}{"path": map[string]interface{}{"type": "string"}},
+			wantNames: []string{"path", "mode"},
+			wantProps: map[string]interface{}{"path": map[string]interface{}{"type": "string"}},
+		},
+		{
+			name:      "decoded JSON list",
+			required:  []interface{}{"path", 42, "mode"},
+			props:     map[string]interface{}{},
+			wantNames: []string{"path", "mode"},
+			wantProps: map[string]interface{}{},
+		},
+		{
+			name:      "single string",
+			required:  "path",
+			props:     map[string]interface{}{},
+			wantNames: []string{"path"},
+			wantProps: map[string]interface{}{},
+		},
+		{
+			name: "property-level flags",
+			props: map[string]interface{}{
+				"path":  map[string]interface{}{"type": "string", "required": true},
+				"force": map[string]interface{}{"type": "boolean", "required": false},
+			},
+			wantNames: []string{"path"},
+			wantProps: map[string]interface{}{
+				"path":  map[string]interface{}{"type": "string"},
+				"force": map[string]interface{}{"type": "boolean"},
+			},
+		},
+		{
+			name:     "deduplicated in order",
+			required: []string{"b", "a", "b", ""},
+			props: map[string]interface{}{
+				"a": map[string]interface{}{"required": true},
+				"c": map[string]interface{}{"required": true},
+			},
+			wantNames: []string{"b", "a", "c"},
+			wantProps: map[string]interface{}{
+				"a": map[string]interface{}{},
+				"c": map[string]interface{}{},
+			},
+		},
+		{
+			name:      "object schema without properties",
+			required:  []interface{}{"query"},
+			props:     map[string]interface{}{},
+			wantNames: []string{"query"},
+			wantProps: map[string]interface{}{},
+		},
+		{
+			name:      "nothing required",
+			props:     map[string]interface{}{"path": map[string]interface{}{"type": "string"}},
+			wantProps: map[string]interface{}{"path": map[string]interface{}{"type": "string"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			props, names := requiredFields(tt.required, tt.props)
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("names = %v, want %v", names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(props, tt.wantProps) {
+				t.Errorf("props = %v, want %v", props, tt.wantProps)
+			}
+		})
+	}
+}
+
+func TestRequiredFieldsLeavesInputUnmodified(t *testing.T) {
+	path := map[string]interface{}{"type": "string", "required": true}
+	props := map[string]interface{}{"path": path}
+
+	cleaned, _ := requiredFields(nil, props)
+
+	if _, flagged := path["required"]; !flagged {
+		t.Error("required flag was removed from the input property")
+	}
+	if props["path"].(map[string]interface{})["required"] != true {
+		t.Error("input properties were modified")
+	}
+	if _, flagged := cleaned["path"].(map[string]interface{})["required"]; flagged {
+		t.Error("required flag was not stripped from the copy")
+	}
+}
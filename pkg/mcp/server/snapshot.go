@@ -0,0 +1,112 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// metricsSnapshot is the subset of a GetMetrics document restored on startup
+type metricsSnapshot struct {
+	Server struct {
+		RequestCount int64 `json:"request_count"`
+		ErrorCount   int64 `json:"error_count"`
+	} `json:"server"`
+	Tools     map[string]int64 `json:"tools"`
+	Histogram struct {
+		Buckets []struct {
+			LE    string `json:"le_ms"`
+			Count int64  `json:"count"`
+		} `json:"buckets"`
+		SumMs float64 `json:"sum_ms"`
+	} `json:"histogram"`
+}
+
+// SaveSnapshot writes the current metrics to path as JSON. The file is
+// replaced atomically so a crash mid-write never leaves a truncated snapshot.
+func (m *MetricsCollector) SaveSnapshot(path string) error {
+	m.UpdateSystemMetrics()
+
+	data, err := json.MarshalIndent(m.GetMetrics(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal metrics snapshot: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".metrics-snapshot-*")
+	if err != nil {
+		return fmt.Errorf("failed to create metrics snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write metrics snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write metrics snapshot: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save metrics snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// LoadSnapshot merges the cumulative counters from a snapshot written by
+// SaveSnapshot into the collector. A missing file is not an error. Histogram
+// counts are only merged when the bucket boundaries are unchanged.
+func (m *MetricsCollector) LoadSnapshot(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read metrics snapshot: %w", err)
+	}
+
+	var snapshot metricsSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return fmt.Errorf("failed to parse metrics snapshot: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requestCount += snapshot.Server.RequestCount
+	m.errorCount += snapshot.Server.ErrorCount
+	for tool, count := range snapshot.Tools {
+		m.toolCallCount[tool] += count
+	}
+
+	if m.histogramMatches(snapshot) {
+		var previous int64
+		for i, bucket := range snapshot.Histogram.Buckets {
+			// Snapshot counts are cumulative
+			m.histogramCounts[i] += bucket.Count - previous
+			previous = bucket.Count
+		}
+		m.histogramSum += msToDuration(snapshot.Histogram.SumMs)
+	}
+
+	return nil
+}
+
+// histogramMatches reports whether the snapshot was taken with the same buckets.
+// Caller must hold m.mu.
+func (m *MetricsCollector) histogramMatches(snapshot metricsSnapshot) bool {
+	current := m.histogramSnapshot()["buckets"].([]map[string]interface{})
+	if len(current) != len(snapshot.Histogram.Buckets) {
+		return false
+	}
+
+	for i, bucket := range snapshot.Histogram.Buckets {
+		if current[i]["le_ms"] != bucket.LE {
+			return false
+		}
+	}
+
+	return true
+}
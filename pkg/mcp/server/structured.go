@@ -0,0 +1,82 @@
+package server
+
+import (
+	"encoding/json"
+
+	"github.com/eadydb/zephyr/pkg/plugin"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// structuredContentKey is the result metadata key that carries a parsed JSON result
+const structuredContentKey = "structuredContent"
+
+// SetStructuredOutput makes the server return the JSON results of the given
+// tools as parsed values as well as text. The MCP library in use predates the
+// structuredContent result field, so the value is attached under
+// _meta.structuredContent; the text content is kept for clients that ignore it.
+// It must be called before Start.
+func (s *Server) SetStructuredOutput(tools map[string]bool) {
+	s.structured = make(map[string]bool)
+	for tool, enabled := range tools {
+		if enabled {
+			s.structured[tool] = true
+		}
+	}
+}
+
+// withStructuredContent attaches the parsed form of a JSON tool result when the
+// tool opted in. Results published as resources stay out of band.
+func (s *Server) withStructuredContent(toolName string, result interface{}, callResult *mcp.CallToolResult) *mcp.CallToolResult {
+	if !s.structured[toolName] || len(callResult.Content) != 1 {
+		return callResult
+	}
+	if _, isText := callResult.Content[0].(mcp.TextContent); !isText {
+		return callResult
+	}
+
+	value, ok := structuredValue(result)
+	if !ok {
+		return callResult
+	}
+
+	if callResult.Meta == nil {
+		callResult.Meta = make(map[string]any)
+	}
+	callResult.Meta[structuredContentKey] = value
+	return callResult
+}
+
+// structuredValue returns a tool result as a JSON object or array, parsing
+// results that plugins returned as pre-encoded JSON strings
+func structuredValue(result interface{}) (interface{}, bool) {
+	var data []byte
+	switch v := result.(type) {
+	case *plugin.ToolResult:
+		if len(v.Content) != 1 || v.Content[0].Type != plugin.ContentJSON {
+			return nil, false
+		}
+		encoded, err := json.Marshal(v.Content[0].Data)
+		if err != nil {
+			return nil, false
+		}
+		data = encoded
+	case string:
+		data = []byte(v)
+	case map[string]interface{}, []interface{}:
+		return v, true
+	default:
+		return nil, false
+	}
+
+	// Only objects and arrays are structured; scalars read fine as text
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, false
+	}
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		return value, true
+	default:
+		return nil, false
+	}
+}
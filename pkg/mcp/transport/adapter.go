@@ -20,6 +20,25 @@ type TransportAdapter interface {
 	IsHealthy() bool
 }
 
+// NetworkTransport is implemented by transports that listen on a network address
+type NetworkTransport interface {
+	TransportAdapter
+
+	// Addr returns the address the transport is bound to, or "" if it is not listening
+	Addr() string
+}
+
+// ReadinessTransport is implemented by transports that serve a readiness probe
+type ReadinessTransport interface {
+	TransportAdapter
+
+	// SetReady reports whether the server behind the transport can take requests
+	SetReady(ready bool)
+
+	// SetDraining marks the transport as shutting down so readiness fails
+	SetDraining(draining bool)
+}
+
 // TransportConfig holds configuration for any transport protocol
 type TransportConfig struct {
 	Protocol string                 `yaml:"protocol"`
@@ -34,48 +34,76 @@ func (f *Factory) CreateTransport(transportConfig TransportConfig) (TransportAda
 	return CreateTransportFromConfig(transportConfig, f.mcpServer)
 }
 
-// SupportedProtocols returns the list of supported transport protocols
+// SupportedProtocols returns the list of registered transport protocols
 func (f *Factory) SupportedProtocols() []string {
-	return []string{"stdio", "sse", "http"}
+	return RegisteredTransports()
 }
 
 // CreateTransportFromFullConfig creates a transport adapter from full application config
 func CreateTransportFromFullConfig(cfg *config.Config, mcpServer *server.MCPServer) (TransportAdapter, error) {
-	return CreateTransport(cfg.Transport.Protocol, mcpServer, &cfg.Transport)
+	return createTransport(cfg.Transport.Protocol, mcpServer, cfg)
+}
+
+// CreateTransportsFromFullConfig creates an adapter for every enabled protocol,
+// all serving the same MCP server
+func CreateTransportsFromFullConfig(cfg *config.Config, mcpServer *server.MCPServer) ([]TransportAdapter, error) {
+	protocols := cfg.Transport.EnabledProtocols()
+	adapters := make([]TransportAdapter, 0, len(protocols))
+	for _, protocol := range protocols {
+		adapter, err := createTransport(protocol, mcpServer, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("transport %s: %w", protocol, err)
+		}
+		adapters = append(adapters, adapter)
+	}
+	return adapters, nil
 }
 
 // CreateTransportFromConfig is a convenience function that creates a transport
 // adapter directly from TransportConfig (for compatibility with adapter.go interface)
 func CreateTransportFromConfig(transportConfig TransportConfig, mcpServer *server.MCPServer) (TransportAdapter, error) {
-	protocol := transportConfig.Protocol
-
-	switch protocol {
-	case "stdio":
-		return NewSTDIOAdapter(mcpServer), nil
-
-	case "sse":
-		// Extract SSE options from generic options map
-		options := transportConfig.Options
-		sseConfig := SSEConfig{
-			Host:        getStringOption(options, "host", "localhost"),
-			Port:        getIntOption(options, "port", 26841),
-			CORSEnabled: getBoolOption(options, "cors_enabled", true),
-		}
-		return NewSSEAdapter(mcpServer, sseConfig), nil
-
-	case "http":
-		// Extract HTTP options from generic options map
-		options := transportConfig.Options
-		httpConfig := HTTPConfig{
-			Host:    getStringOption(options, "host", "localhost"),
-			Port:    getIntOption(options, "port", 26842),
-			Timeout: getDurationOption(options, "timeout", 30*time.Second),
-		}
-		return NewHTTPAdapter(mcpServer, httpConfig), nil
-
-	default:
-		return nil, fmt.Errorf("unsupported transport protocol: %s", protocol)
+	options := transportConfig.Options
+
+	// Map the generic options onto the typed configuration used by constructors
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			Version: getStringOption(options, "version", ""),
+		},
+		Transport: config.TransportConfig{
+			Protocol: transportConfig.Protocol,
+			SSE: config.SSEConfig{
+				Host:            getStringOption(options, "host", "localhost"),
+				Port:            getIntOption(options, "port", 26841),
+				CORSEnabled:     getBoolOption(options, "cors_enabled", true),
+				IdleTimeout:     getDurationOption(options, "idle_timeout", 60*time.Second),
+				MaxConnections:  getIntOption(options, "max_connections", 0),
+				SSEEndpoint:     getStringOption(options, "sse_endpoint", defaultSSEEndpoint),
+				MessageEndpoint: getStringOption(options, "message_endpoint", defaultMessageEndpoint),
+				HealthPath:      getStringOption(options, "health_path", defaultHealthPath),
+
+				KeepAliveInterval: getDurationOption(options, "keep_alive_interval", defaultKeepAliveInterval),
+				ReadTimeout:       getDurationOption(options, "read_timeout", 0),
+				WriteTimeout:      getDurationOption(options, "write_timeout", 0),
+				ReadHeaderTimeout: getDurationOption(options, "read_header_timeout", 10*time.Second),
+			},
+			HTTP: config.HTTPConfig{
+				Host:           getStringOption(options, "host", "localhost"),
+				Port:           getIntOption(options, "port", 26842),
+				Timeout:        getDurationOption(options, "timeout", 30*time.Second),
+				IdleTimeout:    getDurationOption(options, "idle_timeout", 60*time.Second),
+				MaxConnections: getIntOption(options, "max_connections", 0),
+				EndpointPath:   getStringOption(options, "endpoint_path", defaultHTTPEndpointPath),
+				HealthPath:     getStringOption(options, "health_path", defaultHealthPath),
+				ReadyPath:      getStringOption(options, "ready_path", defaultReadyPath),
+
+				ReadTimeout:       getDurationOption(options, "read_timeout", 0),
+				WriteTimeout:      getDurationOption(options, "write_timeout", 0),
+				ReadHeaderTimeout: getDurationOption(options, "read_header_timeout", 10*time.Second),
+			},
+		},
 	}
+
+	return createTransport(transportConfig.Protocol, mcpServer, cfg)
 }
 
 // Helper functions to extract typed values from options map
@@ -122,24 +150,15 @@ func getDurationOption(options map[string]interface{}, key string, defaultValue
 
 // CreateTransport creates a transport adapter based on the protocol
 func CreateTransport(protocol string, mcpServer *server.MCPServer, cfg *config.TransportConfig) (TransportAdapter, error) {
-	switch protocol {
-	case "stdio":
-		return NewSTDIOAdapter(mcpServer), nil
-	case "sse":
-		sseConfig := SSEConfig{
-			Host:        cfg.SSE.Host,
-			Port:        cfg.SSE.Port,
-			CORSEnabled: cfg.SSE.CORSEnabled,
-		}
-		return NewSSEAdapter(mcpServer, sseConfig), nil
-	case "http":
-		httpConfig := HTTPConfig{
-			Host:    cfg.HTTP.Host,
-			Port:    cfg.HTTP.Port,
-			Timeout: cfg.HTTP.Timeout,
-		}
-		return NewHTTPAdapter(mcpServer, httpConfig), nil
-	default:
+	return createTransport(protocol, mcpServer, &config.Config{Transport: *cfg})
+}
+
+// createTransport looks up the registered constructor for the protocol and builds the adapter
+func createTransport(protocol string, mcpServer *server.MCPServer, cfg *config.Config) (TransportAdapter, error) {
+	constructor, exists := getConstructor(protocol)
+	if !exists {
 		return nil, fmt.Errorf("unsupported transport protocol: %s", protocol)
 	}
+
+	return constructor(mcpServer, cfg)
 }
@@ -2,8 +2,10 @@ package transport
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"sync"
 	"time"
@@ -17,22 +19,67 @@ type HTTPAdapter struct {
 	streamableServer *server.StreamableHTTPServer
 	httpServer       *http.Server
 	config           HTTPConfig
+	listener         net.Listener
 	mu               sync.RWMutex
 	running          bool
+
+	// Readiness is separate from liveness: the transport may be listening while
+	// tools are still registering or the server is draining
+	ready    bool
+	draining bool
 }
 
 // HTTPConfig holds HTTP-specific configuration
 type HTTPConfig struct {
-	Host    string
-	Port    int
-	Timeout time.Duration
+	Host           string
+	Port           int
+	Timeout        time.Duration
+	IdleTimeout    time.Duration
+	MaxConnections int    // 0 means unlimited
+	EndpointPath   string // path the MCP handler is mounted on, defaults to /mcp
+	HealthPath     string // path of the health check, defaults to /health
+	ReadyPath      string // path of the readiness probe, defaults to /ready
+	Version        string // server version reported by the health and error routes
+
+	// TrustedProxies are the peers whose forwarding headers are believed
+	TrustedProxies []*net.IPNet
+
+	// ReadTimeout and WriteTimeout default to Timeout when zero
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	ReadHeaderTimeout time.Duration
 }
 
+const (
+	// defaultHTTPEndpointPath is where the MCP handler is mounted when no path is configured
+	defaultHTTPEndpointPath = "/mcp"
+
+	// defaultReadyPath serves the readiness probe when no path is configured
+	defaultReadyPath = "/ready"
+)
+
 // NewHTTPAdapter creates a new StreamableHTTP transport adapter
 func NewHTTPAdapter(mcpServer *server.MCPServer, config HTTPConfig) *HTTPAdapter {
+	if config.EndpointPath == "" {
+		config.EndpointPath = defaultHTTPEndpointPath
+	}
+	if config.HealthPath == "" {
+		config.HealthPath = defaultHealthPath
+	}
+	if config.ReadyPath == "" {
+		config.ReadyPath = defaultReadyPath
+	}
+	if config.ReadTimeout == 0 {
+		config.ReadTimeout = config.Timeout
+	}
+	if config.WriteTimeout == 0 {
+		config.WriteTimeout = config.Timeout
+	}
+
 	// Create StreamableHTTP server with configuration
 	streamableServer := server.NewStreamableHTTPServer(mcpServer,
-		server.WithEndpointPath("/mcp"),
+		server.WithEndpointPath(config.EndpointPath),
+		server.WithHTTPContextFunc(clientIPContextFunc(config.TrustedProxies)),
 	)
 
 	return &HTTPAdapter{
@@ -42,7 +89,7 @@ func NewHTTPAdapter(mcpServer *server.MCPServer, config HTTPConfig) *HTTPAdapter
 	}
 }
 
-// Start begins the StreamableHTTP transport server
+// Start binds the listener and begins the StreamableHTTP transport server
 func (h *HTTPAdapter) Start(ctx context.Context) error {
 	h.mu.Lock()
 	defer h.mu.Unlock()
@@ -55,40 +102,59 @@ func (h *HTTPAdapter) Start(ctx context.Context) error {
 	mux := http.NewServeMux()
 
 	// Mount the streamable HTTP handler
-	mux.Handle("/mcp", h.streamableServer)
+	mux.Handle(h.config.EndpointPath, h.streamableServer)
 
 	// Add health check endpoint
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("OK"))
-	})
+	mux.HandleFunc(h.config.HealthPath, h.healthHandler)
+	mux.HandleFunc(h.config.ReadyPath, h.readyHandler)
 
 	// Add CORS support for web clients
-	mux.HandleFunc("/", h.corsMiddleware(http.NotFoundHandler()).ServeHTTP)
+	mux.HandleFunc("/", h.corsMiddleware(http.HandlerFunc(h.notFoundHandler)).ServeHTTP)
 
 	addr := fmt.Sprintf("%s:%d", h.config.Host, h.config.Port)
 	h.httpServer = &http.Server{
-		Addr:         addr,
-		Handler:      mux,
-		ReadTimeout:  h.config.Timeout,
-		WriteTimeout: h.config.Timeout,
-		IdleTimeout:  60 * time.Second,
+		Addr:              addr,
+		Handler:           mux,
+		ReadTimeout:       h.config.ReadTimeout,
+		WriteTimeout:      h.config.WriteTimeout,
+		ReadHeaderTimeout: h.config.ReadHeaderTimeout,
+		IdleTimeout:       h.config.IdleTimeout,
+	}
+
+	// Bind before spawning the server so a taken port fails Start
+	listener, err := listen(addr)
+	if err != nil {
+		return err
 	}
+	h.listener = listener
+	listener = newLimitListener(listener, h.config.MaxConnections)
 
-	// Start server in background
+	// Start server in background, signalling once it has taken over the listener
+	ready := make(chan struct{})
 	go func() {
 		defer func() {
 			h.mu.Lock()
 			h.running = false
+			h.listener = nil
 			h.mu.Unlock()
 		}()
 
-		slog.Info("Starting StreamableHTTP server", "address", addr)
-		if err := h.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		close(ready)
+		slog.Info("Starting StreamableHTTP server", "address", listener.Addr().String(), "endpoint", h.config.EndpointPath, "max_connections", h.config.MaxConnections)
+		if err := h.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
 			slog.Error("HTTP server error", "error", err)
 		}
 	}()
 
+	// Connections queue in the bound listener from here on, so the transport is
+	// ready as soon as the serve goroutine is running
+	select {
+	case <-ready:
+	case <-ctx.Done():
+		h.httpServer.Close()
+		return ctx.Err()
+	}
+
 	h.running = true
 	return nil
 }
@@ -111,6 +177,17 @@ func (h *HTTPAdapter) Stop() error {
 	return err
 }
 
+// Addr returns the address the transport is bound to, or "" if it is not listening
+func (h *HTTPAdapter) Addr() string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.listener == nil {
+		return ""
+	}
+	return h.listener.Addr().String()
+}
+
 // Name returns the transport protocol name
 func (h *HTTPAdapter) Name() string {
 	return "http"
@@ -124,6 +201,97 @@ func (h *HTTPAdapter) IsHealthy() bool {
 	return h.running && h.httpServer != nil
 }
 
+// allowProbe accepts GET and HEAD, the methods health checkers use, and answers
+// anything else with 405
+func (h *HTTPAdapter) allowProbe(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return true
+	}
+	w.Header().Set("Allow", "GET, HEAD")
+	h.writeError(w, r, http.StatusMethodNotAllowed, "method not allowed")
+	return false
+}
+
+// healthHandler reports transport liveness as JSON
+func (h *HTTPAdapter) healthHandler(w http.ResponseWriter, r *http.Request) {
+	if !h.allowProbe(w, r) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":    "ok",
+		"transport": h.Name(),
+		"version":   h.config.Version,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// SetReady reports whether the MCP server and its tools are ready for requests
+func (h *HTTPAdapter) SetReady(ready bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ready = ready
+}
+
+// SetDraining marks the transport as shutting down so readiness fails
+func (h *HTTPAdapter) SetDraining(draining bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.draining = draining
+}
+
+// readyHandler reports 200 only once the server is ready and not draining
+func (h *HTTPAdapter) readyHandler(w http.ResponseWriter, r *http.Request) {
+	if !h.allowProbe(w, r) {
+		return
+	}
+
+	h.mu.RLock()
+	status := "ready"
+	switch {
+	case h.draining:
+		status = "draining"
+	case !h.ready || !h.running:
+		status = "starting"
+	}
+	h.mu.RUnlock()
+
+	code := http.StatusOK
+	if status != "ready" {
+		code = http.StatusServiceUnavailable
+	}
+	writeJSON(w, code, map[string]interface{}{
+		"status":    status,
+		"transport": h.Name(),
+		"version":   h.config.Version,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// notFoundHandler answers requests for unknown routes with a JSON error
+func (h *HTTPAdapter) notFoundHandler(w http.ResponseWriter, r *http.Request) {
+	h.writeError(w, r, http.StatusNotFound, "not found")
+}
+
+// writeError writes a structured JSON error response
+func (h *HTTPAdapter) writeError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error":   message,
+		"status":  status,
+		"path":    r.URL.Path,
+		"version": h.config.Version,
+	})
+}
+
+// writeJSON encodes body as JSON with the given status code
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("Failed to encode JSON response", "error", err)
+	}
+}
+
 // corsMiddleware adds CORS headers for HTTP transport
 func (h *HTTPAdapter) corsMiddleware(handler http.Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,135 @@
+package transport
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/eadydb/zephyr/internal/config"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// TransportConstructor builds a transport adapter from the application configuration
+type TransportConstructor func(mcpServer *server.MCPServer, cfg *config.Config) (TransportAdapter, error)
+
+var (
+	constructorsMu sync.RWMutex
+	constructors   = make(map[string]TransportConstructor)
+)
+
+func init() {
+	mustRegisterTransport("stdio", newSTDIOTransport)
+	mustRegisterTransport("sse", newSSETransport)
+	mustRegisterTransport("http", newHTTPTransport)
+}
+
+// RegisterTransport makes a transport available under the given protocol name so
+// it can be selected with transport.protocol. It must be called before the
+// configuration is loaded, typically from an init function.
+func RegisterTransport(name string, constructor TransportConstructor) error {
+	if name == "" {
+		return fmt.Errorf("transport name cannot be empty")
+	}
+	if constructor == nil {
+		return fmt.Errorf("transport constructor cannot be nil")
+	}
+
+	constructorsMu.Lock()
+	defer constructorsMu.Unlock()
+
+	if _, exists := constructors[name]; exists {
+		return fmt.Errorf("transport already registered: %s", name)
+	}
+
+	constructors[name] = constructor
+	config.RegisterProtocol(name)
+
+	return nil
+}
+
+// RegisteredTransports returns the names of all registered transports in sorted order
+func RegisteredTransports() []string {
+	constructorsMu.RLock()
+	defer constructorsMu.RUnlock()
+
+	names := make([]string, 0, len(constructors))
+	for name := range constructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// getConstructor looks up the constructor for a protocol
+func getConstructor(name string) (TransportConstructor, bool) {
+	constructorsMu.RLock()
+	defer constructorsMu.RUnlock()
+
+	constructor, exists := constructors[name]
+	return constructor, exists
+}
+
+// mustRegisterTransport registers a built-in transport, panicking on programmer error
+func mustRegisterTransport(name string, constructor TransportConstructor) {
+	if err := RegisterTransport(name, constructor); err != nil {
+		panic(err)
+	}
+}
+
+// newSTDIOTransport constructs the built-in STDIO transport
+func newSTDIOTransport(mcpServer *server.MCPServer, cfg *config.Config) (TransportAdapter, error) {
+	return NewSTDIOAdapter(mcpServer), nil
+}
+
+// newSSETransport constructs the built-in SSE transport
+func newSSETransport(mcpServer *server.MCPServer, cfg *config.Config) (TransportAdapter, error) {
+	trustedProxies, err := ParseTrustedProxies(cfg.Security.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	sseConfig := SSEConfig{
+		Host:            cfg.Transport.SSE.Host,
+		Port:            cfg.Transport.SSE.Port,
+		CORSEnabled:     cfg.Transport.SSE.CORSEnabled,
+		IdleTimeout:     cfg.Transport.SSE.IdleTimeout,
+		MaxConnections:  cfg.Transport.SSE.MaxConnections,
+		SSEEndpoint:     cfg.Transport.SSE.SSEEndpoint,
+		MessageEndpoint: cfg.Transport.SSE.MessageEndpoint,
+		HealthPath:      cfg.Transport.SSE.HealthPath,
+		TrustedProxies:  trustedProxies,
+
+		KeepAliveInterval: cfg.Transport.SSE.KeepAliveInterval,
+		ReadTimeout:       cfg.Transport.SSE.ReadTimeout,
+		WriteTimeout:      cfg.Transport.SSE.WriteTimeout,
+		ReadHeaderTimeout: cfg.Transport.SSE.ReadHeaderTimeout,
+	}
+	return NewSSEAdapter(mcpServer, sseConfig), nil
+}
+
+// newHTTPTransport constructs the built-in StreamableHTTP transport
+func newHTTPTransport(mcpServer *server.MCPServer, cfg *config.Config) (TransportAdapter, error) {
+	trustedProxies, err := ParseTrustedProxies(cfg.Security.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	httpConfig := HTTPConfig{
+		Host:           cfg.Transport.HTTP.Host,
+		Port:           cfg.Transport.HTTP.Port,
+		Timeout:        cfg.Transport.HTTP.Timeout,
+		IdleTimeout:    cfg.Transport.HTTP.IdleTimeout,
+		MaxConnections: cfg.Transport.HTTP.MaxConnections,
+		EndpointPath:   cfg.Transport.HTTP.EndpointPath,
+		HealthPath:     cfg.Transport.HTTP.HealthPath,
+		ReadyPath:      cfg.Transport.HTTP.ReadyPath,
+		Version:        cfg.Server.Version,
+		TrustedProxies: trustedProxies,
+
+		ReadTimeout:       cfg.Transport.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.Transport.HTTP.WriteTimeout,
+		ReadHeaderTimeout: cfg.Transport.HTTP.ReadHeaderTimeout,
+	}
+	return NewHTTPAdapter(mcpServer, httpConfig), nil
+}
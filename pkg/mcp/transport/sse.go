@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"sync"
 	"time"
@@ -17,24 +18,66 @@ type SSEAdapter struct {
 	sseServer  *server.SSEServer
 	httpServer *http.Server
 	config     SSEConfig
+	listener   net.Listener
 	mu         sync.RWMutex
 	running    bool
 }
 
 // SSEConfig holds SSE-specific configuration
 type SSEConfig struct {
-	Host        string
-	Port        int
-	CORSEnabled bool
+	Host           string
+	Port           int
+	CORSEnabled    bool
+	IdleTimeout    time.Duration
+	MaxConnections int // 0 means unlimited
+
+	// KeepAliveInterval is how often an idle event stream is sent a ping so
+	// proxies do not close it, defaults to 10s
+	KeepAliveInterval time.Duration
+
+	SSEEndpoint     string // path of the event stream, defaults to /sse
+	MessageEndpoint string // path clients post messages to, defaults to /message
+	HealthPath      string // path of the health check, defaults to /health
+
+	// TrustedProxies are the peers whose forwarding headers are believed
+	TrustedProxies []*net.IPNet
+
+	// Leave ReadTimeout and WriteTimeout at zero unless clients reconnect on
+	// their own: either one expiring terminates an open event stream
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	ReadHeaderTimeout time.Duration
 }
 
+const (
+	defaultSSEEndpoint     = "/sse"
+	defaultMessageEndpoint = "/message"
+	defaultHealthPath      = "/health"
+
+	defaultKeepAliveInterval = 10 * time.Second
+)
+
 // NewSSEAdapter creates a new SSE transport adapter
 func NewSSEAdapter(mcpServer *server.MCPServer, config SSEConfig) *SSEAdapter {
+	if config.SSEEndpoint == "" {
+		config.SSEEndpoint = defaultSSEEndpoint
+	}
+	if config.MessageEndpoint == "" {
+		config.MessageEndpoint = defaultMessageEndpoint
+	}
+	if config.HealthPath == "" {
+		config.HealthPath = defaultHealthPath
+	}
+	if config.KeepAliveInterval <= 0 {
+		config.KeepAliveInterval = defaultKeepAliveInterval
+	}
+
 	// Create SSE server with configuration
 	sseServer := server.NewSSEServer(mcpServer,
-		server.WithSSEEndpoint("/sse"),
-		server.WithMessageEndpoint("/message"),
-		server.WithKeepAlive(true),
+		server.WithSSEEndpoint(config.SSEEndpoint),
+		server.WithMessageEndpoint(config.MessageEndpoint),
+		server.WithKeepAliveInterval(config.KeepAliveInterval),
+		server.WithSSEContextFunc(clientIPContextFunc(config.TrustedProxies)),
 	)
 
 	return &SSEAdapter{
@@ -44,7 +87,7 @@ func NewSSEAdapter(mcpServer *server.MCPServer, config SSEConfig) *SSEAdapter {
 	}
 }
 
-// Start begins the SSE transport server
+// Start binds the listener and begins the SSE transport server
 func (s *SSEAdapter) Start(ctx context.Context) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -58,39 +101,63 @@ func (s *SSEAdapter) Start(ctx context.Context) error {
 
 	// Configure CORS if enabled
 	if s.config.CORSEnabled {
-		mux.HandleFunc("/sse", s.corsMiddleware(s.sseServer.SSEHandler()))
-		mux.HandleFunc("/message", s.corsMiddleware(s.sseServer.MessageHandler()))
+		mux.HandleFunc(s.config.SSEEndpoint, s.corsMiddleware(s.sseServer.SSEHandler()))
+		mux.HandleFunc(s.config.MessageEndpoint, s.corsMiddleware(s.sseServer.MessageHandler()))
 	} else {
-		mux.Handle("/sse", s.sseServer.SSEHandler())
-		mux.Handle("/message", s.sseServer.MessageHandler())
+		mux.Handle(s.config.SSEEndpoint, s.sseServer.SSEHandler())
+		mux.Handle(s.config.MessageEndpoint, s.sseServer.MessageHandler())
 	}
 
 	// Add health check endpoint
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc(s.config.HealthPath, func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
 	})
 
 	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
 	s.httpServer = &http.Server{
-		Addr:    addr,
-		Handler: mux,
+		Addr:              addr,
+		Handler:           mux,
+		ReadTimeout:       s.config.ReadTimeout,
+		WriteTimeout:      s.config.WriteTimeout,
+		ReadHeaderTimeout: s.config.ReadHeaderTimeout,
+		IdleTimeout:       s.config.IdleTimeout,
+	}
+
+	// Bind before spawning the server so a taken port fails Start
+	listener, err := listen(addr)
+	if err != nil {
+		return err
 	}
+	s.listener = listener
+	listener = newLimitListener(listener, s.config.MaxConnections)
 
-	// Start server in background
+	// Start server in background, signalling once it has taken over the listener
+	ready := make(chan struct{})
 	go func() {
 		defer func() {
 			s.mu.Lock()
 			s.running = false
+			s.listener = nil
 			s.mu.Unlock()
 		}()
 
-		slog.Info("Starting SSE server", "address", addr)
-		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		close(ready)
+		slog.Info("Starting SSE server", "address", listener.Addr().String(), "sse_endpoint", s.config.SSEEndpoint, "max_connections", s.config.MaxConnections)
+		if err := s.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
 			slog.Error("SSE server error", "error", err)
 		}
 	}()
 
+	// Connections queue in the bound listener from here on, so the transport is
+	// ready as soon as the serve goroutine is running
+	select {
+	case <-ready:
+	case <-ctx.Done():
+		s.httpServer.Close()
+		return ctx.Err()
+	}
+
 	s.running = true
 	return nil
 }
@@ -113,6 +180,17 @@ func (s *SSEAdapter) Stop() error {
 	return err
 }
 
+// Addr returns the address the transport is bound to, or "" if it is not listening
+func (s *SSEAdapter) Addr() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
 // Name returns the transport protocol name
 func (s *SSEAdapter) Name() string {
 	return "sse"
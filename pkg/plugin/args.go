@@ -0,0 +1,152 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// The argument helpers read a single value from a tool's args map. A missing or
+// null argument yields the default; a value of the wrong type yields an error
+// wrapping ErrInvalidArguments, so bad input is reported instead of ignored.
+//
+// JSON decodes every number as float64, which the integer helpers accept only
+// when it holds a whole number in range.
+
+// StringArg returns the string argument key, or def if it is absent
+func StringArg(args map[string]interface{}, key, def string) (string, error) {
+	raw, present := lookupArg(args, key)
+	if !present {
+		return def, nil
+	}
+
+	value, ok := raw.(string)
+	if !ok {
+		return "", argTypeError(key, "a string", raw)
+	}
+	return value, nil
+}
+
+// RequiredStringArg returns the string argument key, failing if it is absent or empty
+func RequiredStringArg(args map[string]interface{}, key string) (string, error) {
+	value, err := StringArg(args, key, "")
+	if err != nil {
+		return "", err
+	}
+	if value == "" {
+		return "", fmt.Errorf("%w: %s is required", ErrInvalidArguments, key)
+	}
+	return value, nil
+}
+
+// BoolArg returns the boolean argument key, or def if it is absent
+func BoolArg(args map[string]interface{}, key string, def bool) (bool, error) {
+	raw, present := lookupArg(args, key)
+	if !present {
+		return def, nil
+	}
+
+	value, ok := raw.(bool)
+	if !ok {
+		return false, argTypeError(key, "a boolean", raw)
+	}
+	return value, nil
+}
+
+// FloatArg returns the numeric argument key, or def if it is absent
+func FloatArg(args map[string]interface{}, key string, def float64) (float64, error) {
+	raw, present := lookupArg(args, key)
+	if !present {
+		return def, nil
+	}
+
+	switch value := raw.(type) {
+	case float64:
+		return value, nil
+	case float32:
+		return float64(value), nil
+	case int:
+		return float64(value), nil
+	case int64:
+		return float64(value), nil
+	case json.Number:
+		f, err := value.Float64()
+		if err != nil {
+			return 0, argTypeError(key, "a number", raw)
+		}
+		return f, nil
+	default:
+		return 0, argTypeError(key, "a number", raw)
+	}
+}
+
+// Int64Arg returns the integer argument key, or def if it is absent.
+// Fractional numbers and numbers outside the int64 range are rejected.
+func Int64Arg(args map[string]interface{}, key string, def int64) (int64, error) {
+	raw, present := lookupArg(args, key)
+	if !present {
+		return def, nil
+	}
+
+	switch value := raw.(type) {
+	case int:
+		return int64(value), nil
+	case int64:
+		return value, nil
+	case json.Number:
+		// Parse directly so large integers keep their precision
+		if i, err := strconv.ParseInt(value.String(), 10, 64); err == nil {
+			return i, nil
+		}
+		f, err := value.Float64()
+		if err != nil {
+			return 0, argTypeError(key, "an integer", raw)
+		}
+		return floatToInt64(key, f)
+	case float64:
+		return floatToInt64(key, value)
+	case float32:
+		return floatToInt64(key, float64(value))
+	default:
+		return 0, argTypeError(key, "an integer", raw)
+	}
+}
+
+// IntArg returns the integer argument key, or def if it is absent
+func IntArg(args map[string]interface{}, key string, def int) (int, error) {
+	value, err := Int64Arg(args, key, int64(def))
+	if err != nil {
+		return 0, err
+	}
+	if value < math.MinInt || value > math.MaxInt {
+		return 0, fmt.Errorf("%w: %s is out of range: %d", ErrInvalidArguments, key, value)
+	}
+	return int(value), nil
+}
+
+// lookupArg returns the value of key, treating null the same as absent
+func lookupArg(args map[string]interface{}, key string) (interface{}, bool) {
+	raw, present := args[key]
+	if !present || raw == nil {
+		return nil, false
+	}
+	return raw, true
+}
+
+// floatToInt64 converts a JSON number to an integer if it is whole and in range
+func floatToInt64(key string, f float64) (int64, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f != math.Trunc(f) {
+		return 0, fmt.Errorf("%w: %s must be an integer, got %v", ErrInvalidArguments, key, f)
+	}
+	// float64(math.MaxInt64) rounds up to 2^63, which is itself out of range
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("%w: %s is out of range: %v", ErrInvalidArguments, key, f)
+	}
+	return int64(f), nil
+}
+
+// argTypeError reports an argument of the wrong type
+func argTypeError(key, want string, got interface{}) error {
+	return fmt.Errorf("%w: %s must be %s, got %T", ErrInvalidArguments, key, want, got)
+}
@@ -0,0 +1,140 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// BindArgs decodes a tool's args map into the struct pointed to by dst, using the
+// fields' json tags for argument names. Arguments absent from args leave their
+// field untouched, so defaults can be set on dst before binding. Unknown
+// arguments are ignored.
+//
+// After decoding, fields are checked against their validate tag, a comma
+// separated list of rules:
+//
+//	required      the argument is present, not null, and not an empty string
+//	oneof=a b c   a string field holds one of the listed values
+//	min=N, max=N  a numeric field lies within the bound
+//
+// Every failure wraps ErrInvalidArguments.
+func BindArgs(args map[string]interface{}, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind target must be a non-nil pointer to a struct, got %T", dst)
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArguments, err)
+	}
+
+	if err := json.Unmarshal(data, dst); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return fmt.Errorf("%w: %s must be %s, got %s", ErrInvalidArguments, typeErr.Field, typeErr.Type, typeErr.Value)
+		}
+		return fmt.Errorf("%w: %v", ErrInvalidArguments, err)
+	}
+
+	return validateArgs(args, rv.Elem())
+}
+
+// validateArgs applies the validate tags of the struct's fields
+func validateArgs(args map[string]interface{}, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		rules := field.Tag.Get("validate")
+		if rules == "" || !field.IsExported() {
+			continue
+		}
+
+		name := argName(field)
+		value := rv.Field(i)
+		for _, rule := range strings.Split(rules, ",") {
+			if err := checkRule(args, name, value, rule); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkRule applies a single validate rule to a field
+func checkRule(args map[string]interface{}, name string, value reflect.Value, rule string) error {
+	rule = strings.TrimSpace(rule)
+	key, param, _ := strings.Cut(rule, "=")
+
+	switch key {
+	case "required":
+		raw, present := lookupArg(args, name)
+		if !present {
+			return fmt.Errorf("%w: %s is required", ErrInvalidArguments, name)
+		}
+		if s, ok := raw.(string); ok && s == "" {
+			return fmt.Errorf("%w: %s is required", ErrInvalidArguments, name)
+		}
+
+	case "oneof":
+		if _, present := lookupArg(args, name); !present || value.Kind() != reflect.String {
+			return nil
+		}
+		allowed := strings.Fields(param)
+		for _, option := range allowed {
+			if value.String() == option {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: %s must be one of %s, got %q", ErrInvalidArguments, name, strings.Join(allowed, ", "), value.String())
+
+	case "min", "max":
+		bound, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s rule on argument %s: %w", key, name, err)
+		}
+		number, ok := numericValue(value)
+		if !ok {
+			return nil
+		}
+		if key == "min" && number < bound {
+			return fmt.Errorf("%w: %s must be at least %s", ErrInvalidArguments, name, param)
+		}
+		if key == "max" && number > bound {
+			return fmt.Errorf("%w: %s must be at most %s", ErrInvalidArguments, name, param)
+		}
+
+	default:
+		return fmt.Errorf("unknown validate rule %q on argument %s", rule, name)
+	}
+
+	return nil
+}
+
+// argName returns the argument name a struct field binds to
+func argName(field reflect.StructField) string {
+	if tag := field.Tag.Get("json"); tag != "" {
+		if name, _, _ := strings.Cut(tag, ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// numericValue returns the value of a numeric field as a float64
+func numericValue(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
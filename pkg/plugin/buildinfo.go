@@ -0,0 +1,117 @@
+package plugin
+
+import (
+	"debug/buildinfo"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+// BuildManifestFile is the optional file in a plugin directory describing how
+// the plugin was built. Without it the build info embedded in the .so is used.
+const BuildManifestFile = "build.json"
+
+// BuildManifest records the toolchain and module versions a binary was built
+// with. plugin.Open rejects plugins whose Go version or shared packages differ
+// from the host's.
+type BuildManifest struct {
+	GoVersion string            `json:"go_version"`
+	Modules   map[string]string `json:"modules"` // module path -> version
+}
+
+// HostBuildManifest returns the build manifest of the running binary
+func HostBuildManifest() BuildManifest {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return BuildManifest{}
+	}
+	return manifestFromBuildInfo(info)
+}
+
+// readPluginBuildManifest returns the plugin's build manifest from build.json if
+// it ships one, and otherwise from the build info embedded in its library
+func readPluginBuildManifest(pluginDir, libraryPath string) (BuildManifest, error) {
+	data, err := os.ReadFile(filepath.Join(pluginDir, BuildManifestFile))
+	if err == nil {
+		var manifest BuildManifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return BuildManifest{}, fmt.Errorf("failed to parse %s: %w", BuildManifestFile, err)
+		}
+		return manifest, nil
+	}
+	if !os.IsNotExist(err) {
+		return BuildManifest{}, fmt.Errorf("failed to read %s: %w", BuildManifestFile, err)
+	}
+
+	info, err := buildinfo.ReadFile(libraryPath)
+	if err != nil {
+		return BuildManifest{}, fmt.Errorf("failed to read build info: %w", err)
+	}
+	return manifestFromBuildInfo(info), nil
+}
+
+// manifestFromBuildInfo collects the Go version and dependency versions. The
+// main module is left out since its version changes with every commit.
+func manifestFromBuildInfo(info *debug.BuildInfo) BuildManifest {
+	manifest := BuildManifest{
+		GoVersion: info.GoVersion,
+		Modules:   make(map[string]string, len(info.Deps)),
+	}
+	for _, dep := range info.Deps {
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		manifest.Modules[dep.Path] = dep.Version
+	}
+	return manifest
+}
+
+// CompareBuildManifests lists the differences between the host and plugin builds
+// that commonly make plugin.Open fail. Modules only one side uses are ignored.
+func CompareBuildManifests(host, plugin BuildManifest) []string {
+	var mismatches []string
+
+	if host.GoVersion != "" && plugin.GoVersion != "" && host.GoVersion != plugin.GoVersion {
+		mismatches = append(mismatches, fmt.Sprintf("go version: host %s, plugin %s", host.GoVersion, plugin.GoVersion))
+	}
+
+	for path, pluginVersion := range plugin.Modules {
+		hostVersion, shared := host.Modules[path]
+		if !shared || hostVersion == pluginVersion || isDevelVersion(hostVersion) || isDevelVersion(pluginVersion) {
+			continue
+		}
+		mismatches = append(mismatches, fmt.Sprintf("%s: host %s, plugin %s", path, hostVersion, pluginVersion))
+	}
+
+	sort.Strings(mismatches)
+	return mismatches
+}
+
+// isDevelVersion reports whether a module version is unknown, as for local replacements
+func isDevelVersion(version string) bool {
+	return version == "" || version == "(devel)"
+}
+
+// checkBuildCompatibility warns about build differences between the host and a
+// plugin and returns them so a failed open can mention them
+func (pm *PluginManager) checkBuildCompatibility(name, pluginDir, libraryPath string) []string {
+	manifest, err := readPluginBuildManifest(pluginDir, libraryPath)
+	if err != nil {
+		slog.Debug("Could not verify plugin build", "plugin", name, "error", err)
+		return nil
+	}
+
+	mismatches := CompareBuildManifests(pm.hostBuild, manifest)
+	if len(mismatches) > 0 {
+		slog.Warn("Plugin was built differently from the host and may fail to open",
+			"plugin", name,
+			"mismatches", strings.Join(mismatches, "; "),
+			"hint", "rebuild the plugin with the host's Go toolchain and go.mod")
+	}
+	return mismatches
+}
@@ -0,0 +1,39 @@
+package plugin
+
+import (
+	"context"
+)
+
+// requestIDKey is the context key for the per-request ID
+type requestIDKey struct{}
+
+// clientIPKey is the context key for the calling client's IP
+type clientIPKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the given request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or "" if there is none.
+// Plugins can use it to correlate their own logs with the server's.
+func RequestIDFromContext(ctx context.Context) string {
+	if requestID, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return requestID
+	}
+	return ""
+}
+
+// WithClientIP returns a copy of ctx carrying the address of the calling client
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIPFromContext returns the client IP stored in ctx, or "" for transports
+// without a network peer such as STDIO
+func ClientIPFromContext(ctx context.Context) string {
+	if ip, ok := ctx.Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+	return ""
+}
@@ -3,6 +3,7 @@ package plugin
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"plugin"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -30,6 +32,12 @@ type DynamicPlugin interface {
 	InputSchema() map[string]interface{}
 }
 
+// CapabilitiesProvider is optionally implemented by a DynamicPlugin to advertise
+// sub-operations, limits, and other features beyond its MCP tool definition
+type CapabilitiesProvider interface {
+	Capabilities() map[string]interface{}
+}
+
 // PluginMetadata contains plugin metadata from plugin.json
 type PluginMetadata struct {
 	Name         string                 `json:"name"`
@@ -41,6 +49,23 @@ type PluginMetadata struct {
 	Dependencies []string               `json:"dependencies"`
 	Permissions  []string               `json:"permissions"`
 	ConfigSchema map[string]interface{} `json:"config_schema"`
+
+	// Critical plugins must load before the server accepts requests
+	Critical bool `json:"critical"`
+
+	// InputSchema advertises the tool's arguments before the plugin is loaded
+	InputSchema map[string]interface{} `json:"input_schema,omitempty"`
+
+	// Deprecated marks the tool for removal; ReplacedBy optionally names its successor
+	Deprecated bool   `json:"deprecated,omitempty"`
+	ReplacedBy string `json:"replaced_by,omitempty"`
+
+	// SelfTest is the input of a probe call made at startup with --self-test
+	SelfTest map[string]interface{} `json:"self_test,omitempty"`
+
+	// EnabledDefault is whether the server loads the plugin when the configuration
+	// does not say; set it to false to ship an opt-in plugin. Defaults to true.
+	EnabledDefault bool `json:"enabled_default"`
 }
 
 // LoadedPlugin represents a loaded plugin with its metadata and instance
@@ -51,8 +76,41 @@ type LoadedPlugin struct {
 	LoadedAt  time.Time
 	Directory string
 	Enabled   bool
+
+	// Time spent in plugin.Open and Initialize while loading
+	OpenDuration time.Duration
+	InitDuration time.Duration
 }
 
+// LoadDuration returns the total time spent loading the plugin
+func (lp *LoadedPlugin) LoadDuration() time.Duration {
+	return lp.OpenDuration + lp.InitDuration
+}
+
+const (
+	// defaultOpenTimeout bounds a single plugin.Open call when no timeout is configured
+	defaultOpenTimeout = 30 * time.Second
+
+	// openRetryDelay is the pause before retrying a failed plugin.Open
+	openRetryDelay = 500 * time.Millisecond
+
+	// defaultDiscoveryMaxDepth limits recursive discovery when no depth is configured
+	defaultDiscoveryMaxDepth = 5
+)
+
+// DefaultMaxPlugins caps how many plugins discovery accepts when no limit is
+// configured, guarding against a plugins directory pointed at the wrong tree
+const DefaultMaxPlugins = 256
+
+// ErrPluginOpenTimeout is returned when opening a plugin file does not complete in time
+var ErrPluginOpenTimeout = errors.New("plugin open timed out")
+
+// ErrPluginUnloaded is the cancellation cause of calls still running when their plugin is unloaded
+var ErrPluginUnloaded = errors.New("plugin unloaded")
+
+// ErrPluginNotBuilt is returned when a plugin directory has a plugin.json but no compiled library
+var ErrPluginNotBuilt = errors.New("plugin not built")
+
 // PluginManager manages dynamic loading and lifecycle of plugins
 type PluginManager struct {
 	mu          sync.RWMutex
@@ -61,96 +119,482 @@ type PluginManager struct {
 	registry    ToolRegistry             // existing registry for integration
 	baseDir     string                   // plugins base directory
 	discovered  map[string]PluginMetadata
+	notBuilt    map[string]bool // discovered plugins without a compiled .so
 	loaded      map[string]*DynamicPluginAdapter
+	loading     map[string]*pendingLoad // plugins being opened outside the lock
+	unloading   map[string]bool         // plugins draining calls outside the lock
+	openTimeout time.Duration           // maximum time to wait for plugin.Open
+
+	lifecycleTimeout time.Duration // maximum time to wait for Initialize and Shutdown
+
+	loadConcurrency int // plugins LoadPlugins opens at once
+
+	recursive bool // search nested directories for plugin.json
+	maxDepth  int  // deepest directory level searched when recursive
+
+	maxPlugins int // most plugins discovery accepts
+
+	hostBuild BuildManifest // compared against each plugin's build before opening it
+
+	lifecycle map[string]*PluginLifecycleStats // load, unload, and reload counters per plugin
+
+	lastDiscovery DiscoveryReport // outcome of the latest DiscoverPlugins scan
+}
+
+// PluginLifecycleStats counts a plugin's lifecycle events since the manager
+// started. A plugin whose counters keep climbing is flapping.
+type PluginLifecycleStats struct {
+	Loads         int64     `json:"loads"`
+	Unloads       int64     `json:"unloads"`
+	Reloads       int64     `json:"reloads"`
+	LastLoadError string    `json:"last_load_error,omitempty"`
+	LastErrorAt   time.Time `json:"last_error_at,omitzero"`
+}
+
+// PluginManagerOptions holds optional configuration for the plugin manager
+type PluginManagerOptions struct {
+	OpenTimeout      time.Duration
+	LifecycleTimeout time.Duration
+
+	// LoadConcurrency is how many plugins LoadPlugins opens and initializes at
+	// once; dependencies still load before the plugins that need them
+	LoadConcurrency int
+
+	// Recursive discovery finds plugin.json files in nested directories up to
+	// MaxDepth levels below the base directory. Flat scanning is the default.
+	Recursive bool
+	MaxDepth  int
+
+	// MaxPlugins caps the number of plugins discovered; discovery logs an error
+	// and ignores further plugins once it is reached
+	MaxPlugins int
 }
 
 // NewPluginManager creates a new plugin manager
 func NewPluginManager(baseDir string, registry ToolRegistry) *PluginManager {
+	return NewPluginManagerWithOptions(baseDir, registry, nil)
+}
+
+// NewPluginManagerWithOptions creates a new plugin manager with custom options
+func NewPluginManagerWithOptions(baseDir string, registry ToolRegistry, opts *PluginManagerOptions) *PluginManager {
+	if opts == nil {
+		opts = &PluginManagerOptions{}
+	}
+
+	// Set defaults
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = defaultOpenTimeout
+	}
+	if opts.LifecycleTimeout <= 0 {
+		opts.LifecycleTimeout = DefaultLifecycleTimeout
+	}
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = defaultDiscoveryMaxDepth
+	}
+	if opts.LoadConcurrency <= 0 {
+		opts.LoadConcurrency = DefaultLoadConcurrency
+	}
+	if opts.MaxPlugins <= 0 {
+		opts.MaxPlugins = DefaultMaxPlugins
+	}
+
 	return &PluginManager{
 		plugins:     make(map[string]*LoadedPlugin),
 		pluginPaths: make(map[string]string),
 		registry:    registry,
 		baseDir:     baseDir,
 		discovered:  make(map[string]PluginMetadata),
+		notBuilt:    make(map[string]bool),
 		loaded:      make(map[string]*DynamicPluginAdapter),
+		loading:     make(map[string]*pendingLoad),
+		unloading:   make(map[string]bool),
+		openTimeout: opts.OpenTimeout,
+
+		lifecycleTimeout: opts.LifecycleTimeout,
+
+		loadConcurrency: opts.LoadConcurrency,
+
+		recursive: opts.Recursive,
+		maxDepth:  opts.MaxDepth,
+
+		maxPlugins: opts.MaxPlugins,
+
+		hostBuild: HostBuildManifest(),
+
+		lifecycle: make(map[string]*PluginLifecycleStats),
 	}
 }
 
-// DiscoverPlugins scans the plugins directory for available plugins
+// Discovery outcomes of a scanned entry
+const (
+	DiscoveryIncluded = "included"
+	DiscoverySkipped  = "skipped"
+)
+
+// DiscoveryEntry records what discovery decided about one scanned path
+type DiscoveryEntry struct {
+	Path   string `json:"path"`
+	Name   string `json:"name,omitempty"` // plugin name, once its metadata was read
+	Status string `json:"status"`         // DiscoveryIncluded or DiscoverySkipped
+	Reason string `json:"reason"`
+}
+
+// DiscoveryReport describes the most recent plugin discovery scan
+type DiscoveryReport struct {
+	ScannedAt time.Time        `json:"scanned_at"`
+	BaseDir   string           `json:"base_dir"`
+	Recursive bool             `json:"recursive"`
+	Entries   []DiscoveryEntry `json:"entries"`
+}
+
+// skip records a path discovery passed over
+func (r *DiscoveryReport) skip(path, name, reason string) {
+	r.Entries = append(r.Entries, DiscoveryEntry{Path: path, Name: name, Status: DiscoverySkipped, Reason: reason})
+}
+
+// include records a discovered plugin
+func (r *DiscoveryReport) include(path, name, reason string) {
+	r.Entries = append(r.Entries, DiscoveryEntry{Path: path, Name: name, Status: DiscoveryIncluded, Reason: reason})
+}
+
+// DiscoveryReport returns the report of the most recent discovery scan
+func (pm *PluginManager) DiscoveryReport() DiscoveryReport {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	report := pm.lastDiscovery
+	report.Entries = append([]DiscoveryEntry(nil), report.Entries...)
+	return report
+}
+
+// DiscoverPlugins scans the plugins directory for available plugins, recording
+// why each scanned entry was included or skipped in the discovery report
 func (pm *PluginManager) DiscoverPlugins() error {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
+	report := DiscoveryReport{ScannedAt: time.Now(), BaseDir: pm.baseDir, Recursive: pm.recursive}
+	defer func() { pm.lastDiscovery = report }()
+
 	// Create base directory if it doesn't exist
 	if err := os.MkdirAll(pm.baseDir, 0o755); err != nil {
 		return fmt.Errorf("failed to create plugins directory: %w", err)
 	}
 
 	// Scan for plugin directories
-	entries, err := os.ReadDir(pm.baseDir)
+	var pluginDirs []string
+	var err error
+	if pm.recursive {
+		pluginDirs, err = pm.findPluginDirsRecursive(&report)
+	} else {
+		pluginDirs, err = pm.findPluginDirs(&report)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to read plugins directory: %w", err)
+		return err
 	}
 
-	for _, entry := range entries {
-		if !entry.IsDir() {
+	seen := make(map[string]string) // plugin name -> directory in this scan
+	limitReached := false
+	for _, pluginDir := range pluginDirs {
+		if limitReached {
+			report.skip(pluginDir, "", fmt.Sprintf("plugin limit of %d reached", pm.maxPlugins))
 			continue
 		}
 
-		pluginDir := filepath.Join(pm.baseDir, entry.Name())
 		metadataPath := filepath.Join(pluginDir, "plugin.json")
 
-		// Check if plugin.json exists
-		if _, err := os.Stat(metadataPath); os.IsNotExist(err) {
-			continue
-		}
-
 		// Load metadata
 		metadata, err := pm.loadMetadata(metadataPath)
 		if err != nil {
-			slog.Warn("Failed to load metadata for plugin", "plugin", entry.Name(), "error", err)
+			slog.Warn("Failed to load metadata for plugin", "plugin", filepath.Base(pluginDir), "error", err)
+			report.skip(pluginDir, "", "invalid plugin.json: "+err.Error())
 			continue
 		}
 
+		if previous, duplicate := seen[metadata.Name]; duplicate {
+			slog.Warn("Skipping plugin with a duplicate name", "name", metadata.Name, "path", pluginDir, "first", previous)
+			report.skip(pluginDir, metadata.Name, "duplicate plugin name, already found at "+previous)
+			continue
+		}
+		// Plugins from earlier scans that are gone now must not count toward the limit
+		if len(seen) >= pm.maxPlugins {
+			slog.Error("Plugin limit reached, ignoring remaining plugins",
+				"max_plugins", pm.maxPlugins,
+				"path", pluginDir,
+				"hint", "check the plugins directory or raise plugins.discovery.max_plugins")
+			report.skip(pluginDir, metadata.Name, fmt.Sprintf("plugin limit of %d reached", pm.maxPlugins))
+			limitReached = true
+			continue
+		}
+		seen[metadata.Name] = pluginDir
+
 		pm.pluginPaths[metadata.Name] = pluginDir
 		pm.discovered[metadata.Name] = metadata
+
+		// A plugin.json without a library is usually a plugin still in development
+		libraryPath := pluginLibraryPath(pluginDir, metadata.Name)
+		if _, err := os.Stat(libraryPath); err != nil {
+			pm.notBuilt[metadata.Name] = true
+			slog.Warn("Discovered plugin is not built",
+				"name", metadata.Name,
+				"path", pluginDir,
+				"missing", libraryPath,
+				"hint", "go build -buildmode=plugin -o "+libraryPath)
+			report.include(pluginDir, metadata.Name, "not built: missing "+libraryPath)
+			continue
+		}
+		delete(pm.notBuilt, metadata.Name)
+
 		slog.Info("Discovered plugin", "name", metadata.Name, "version", metadata.Version, "path", pluginDir)
+		report.include(pluginDir, metadata.Name, "plugin.json and library found")
 	}
 
 	return nil
 }
 
+// pluginLibraryPath returns the path of the compiled library for a plugin
+func pluginLibraryPath(pluginDir, name string) string {
+	return filepath.Join(pluginDir, name+".so")
+}
+
+// IsBuilt reports whether a discovered plugin has a compiled library to load
+func (pm *PluginManager) IsBuilt(name string) bool {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	_, discovered := pm.discovered[name]
+	return discovered && !pm.notBuilt[name]
+}
+
+// findPluginDirs returns the immediate subdirectories of the base directory that
+// contain a plugin.json, recording the other entries in the report
+func (pm *PluginManager) findPluginDirs(report *DiscoveryReport) ([]string, error) {
+	entries, err := os.ReadDir(pm.baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugins directory: %w", err)
+	}
+
+	var dirs []string
+	for _, entry := range entries {
+		pluginDir := filepath.Join(pm.baseDir, entry.Name())
+		if !entry.IsDir() {
+			report.skip(pluginDir, "", "not a directory")
+			continue
+		}
+
+		if _, err := os.Stat(filepath.Join(pluginDir, "plugin.json")); err == nil {
+			dirs = append(dirs, pluginDir)
+		} else {
+			report.skip(pluginDir, "", "no plugin.json")
+		}
+	}
+	return dirs, nil
+}
+
+// findPluginDirsRecursive walks the base directory up to maxDepth levels deep and
+// returns every directory that contains a plugin.json, recording the directories
+// passed over in the report. A plugin directory's own subdirectories are not searched.
+func (pm *PluginManager) findPluginDirsRecursive(report *DiscoveryReport) ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(pm.baseDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			slog.Warn("Failed to read plugin directory", "path", path, "error", err)
+			if d != nil && d.IsDir() && path != pm.baseDir {
+				report.skip(path, "", "unreadable: "+err.Error())
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if path == pm.baseDir {
+			return nil
+		}
+		if !d.IsDir() {
+			report.skip(path, "", "not a directory")
+			return nil
+		}
+
+		rel, err := filepath.Rel(pm.baseDir, path)
+		if err != nil {
+			return err
+		}
+		if depth := len(strings.Split(rel, string(filepath.Separator))); depth > pm.maxDepth {
+			report.skip(path, "", fmt.Sprintf("deeper than max_depth %d", pm.maxDepth))
+			return filepath.SkipDir
+		}
+
+		if _, err := os.Stat(filepath.Join(path, "plugin.json")); err == nil {
+			dirs = append(dirs, path)
+			// Past the plugin limit there is no point walking a runaway tree
+			if len(dirs) > pm.maxPlugins {
+				return filepath.SkipAll
+			}
+			return filepath.SkipDir
+		}
+		report.skip(path, "", "no plugin.json")
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk plugins directory: %w", err)
+	}
+	return dirs, nil
+}
+
 // LoadPlugin loads a specific plugin by name
 func (pm *PluginManager) LoadPlugin(name string) error {
+	pm.mu.Lock()
+	pluginInfo, pluginDir, err := pm.prepareLoadLocked(name)
+	if err != nil {
+		pm.mu.Unlock()
+		return err
+	}
+	pending := pm.beginLoadLocked(name)
+	pm.mu.Unlock()
+
+	_, err = pm.completeLoad(name, pluginInfo, pluginDir, pending, true)
+	return err
+}
+
+// pendingLoad is a plugin being opened outside the lock. done is closed once
+// the attempt finishes, after which err holds its outcome.
+type pendingLoad struct {
+	done chan struct{}
+	err  error
+}
+
+// beginLoadLocked marks a plugin as loading. The caller must hold pm.mu and
+// finish the load with completeLoad.
+func (pm *PluginManager) beginLoadLocked(name string) *pendingLoad {
+	pending := &pendingLoad{done: make(chan struct{})}
+	pm.loading[name] = pending
+	return pending
+}
+
+// completeLoad opens and initializes a plugin without holding pm.mu, so other
+// plugins can load and be listed meanwhile, then installs it, optionally
+// registering it with the tool registry. Callers waiting on pending are released.
+func (pm *PluginManager) completeLoad(name string, pluginInfo PluginMetadata, pluginDir string, pending *pendingLoad, register bool) (*DynamicPluginAdapter, error) {
+	opened, err := pm.openPlugin(name, pluginDir)
+
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
+	delete(pm.loading, name)
+
+	var adapter *DynamicPluginAdapter
+	if err == nil {
+		adapter, err = pm.installLocked(name, pluginInfo, pluginDir, opened, register)
+	}
+	pm.recordLoadLocked(name, err)
+
+	pending.err = err
+	close(pending.done)
+	return adapter, err
+}
 
+// prepareLoadLocked returns the metadata and directory of a discovered plugin
+// that is neither loaded nor loading. The caller must hold pm.mu.
+func (pm *PluginManager) prepareLoadLocked(name string) (PluginMetadata, string, error) {
 	pluginInfo, exists := pm.discovered[name]
 	if !exists {
-		return fmt.Errorf("plugin %s not found", name)
+		return pluginInfo, "", fmt.Errorf("plugin %s not found", name)
 	}
 
 	// Check if already loaded
 	if pm.loaded[name] != nil {
-		return fmt.Errorf("plugin %s already loaded", name)
+		return pluginInfo, "", fmt.Errorf("plugin %s already loaded", name)
+	}
+	if pm.loading[name] != nil {
+		return pluginInfo, "", fmt.Errorf("plugin %s is already loading", name)
+	}
+	if pm.unloading[name] {
+		return pluginInfo, "", fmt.Errorf("plugin %s is still unloading", name)
 	}
 
-	// Get plugin directory path
 	pluginDir, exists := pm.pluginPaths[name]
 	if !exists {
-		return fmt.Errorf("plugin directory for %s not found", name)
+		return pluginInfo, "", fmt.Errorf("plugin directory for %s not found", name)
+	}
+
+	return pluginInfo, pluginDir, nil
+}
+
+// recordLoadLocked records the outcome of a load attempt. The caller must hold pm.mu.
+func (pm *PluginManager) recordLoadLocked(name string, err error) {
+	if errors.Is(err, ErrPluginNotBuilt) {
+		pm.notBuilt[name] = true
+	} else {
+		delete(pm.notBuilt, name)
+	}
+
+	stats := pm.lifecycleLocked(name)
+	if err != nil {
+		stats.LastLoadError = err.Error()
+		stats.LastErrorAt = time.Now()
+	} else {
+		stats.Loads++
+	}
+}
+
+// lifecycleLocked returns the lifecycle counters for a plugin, creating them on
+// first use. The caller must hold pm.mu for writing.
+func (pm *PluginManager) lifecycleLocked(name string) *PluginLifecycleStats {
+	stats, exists := pm.lifecycle[name]
+	if !exists {
+		stats = &PluginLifecycleStats{}
+		pm.lifecycle[name] = stats
+	}
+	return stats
+}
+
+// LifecycleStats returns a copy of each plugin's lifecycle counters
+func (pm *PluginManager) LifecycleStats() map[string]PluginLifecycleStats {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	result := make(map[string]PluginLifecycleStats, len(pm.lifecycle))
+	for name, stats := range pm.lifecycle {
+		result[name] = *stats
 	}
+	return result
+}
+
+// openedPlugin is a plugin library that has been opened and initialized but
+// not yet installed in the manager
+type openedPlugin struct {
+	handle       *plugin.Plugin
+	plugin       DynamicPlugin
+	openDuration time.Duration
+	initDuration time.Duration
+}
+
+// openPlugin opens and initializes a plugin library. It does not touch the
+// manager's state, so plugins can be opened concurrently without holding pm.mu.
+func (pm *PluginManager) openPlugin(name, pluginDir string) (*openedPlugin, error) {
+	// Fail clearly when there is nothing to open
+	libraryPath := pluginLibraryPath(pluginDir, name)
+	if _, err := os.Stat(libraryPath); err != nil {
+		return nil, fmt.Errorf("%w: %s has no compiled library at %s", ErrPluginNotBuilt, name, libraryPath)
+	}
+
+	mismatches := pm.checkBuildCompatibility(name, pluginDir, libraryPath)
 
 	// Open the plugin file
-	p, err := plugin.Open(filepath.Join(pluginDir, name+".so"))
+	openStart := time.Now()
+	p, err := pm.openPluginFile(libraryPath)
+	openDuration := time.Since(openStart)
 	if err != nil {
-		return fmt.Errorf("failed to open plugin %s: %v", name, err)
+		if errors.Is(err, ErrPluginOpenTimeout) {
+			return nil, fmt.Errorf("timed out opening plugin %s: %w", name, err)
+		}
+		if len(mismatches) > 0 {
+			return nil, fmt.Errorf("failed to open plugin %s: %v (build differs from host: %s)", name, err, strings.Join(mismatches, "; "))
+		}
+		return nil, fmt.Errorf("failed to open plugin %s: %v", name, err)
 	}
 
 	// Look up the DynamicPlugin symbol
 	sym, err := p.Lookup("Plugin")
 	if err != nil {
-		return fmt.Errorf("failed to find Plugin symbol in %s: %v", name, err)
+		return nil, fmt.Errorf("failed to find Plugin symbol in %s: %v", name, err)
 	}
 
 	// Try to assert as pointer to DynamicPlugin first
@@ -160,48 +604,138 @@ func (pm *PluginManager) LoadPlugin(name string) error {
 	} else if directPlugin, ok := sym.(DynamicPlugin); ok {
 		dynamicPlugin = directPlugin
 	} else {
-		return fmt.Errorf("plugin %s does not implement DynamicPlugin interface (got %T)", name, sym)
+		return nil, fmt.Errorf("plugin %s does not implement DynamicPlugin interface (got %T)", name, sym)
 	}
 
 	// Initialize the plugin
-	if err := dynamicPlugin.Initialize(); err != nil {
-		return fmt.Errorf("failed to initialize plugin %s: %v", name, err)
+	initStart := time.Now()
+	if err := CallWithTimeout(name+" Initialize", pm.lifecycleTimeout, dynamicPlugin.Initialize); err != nil {
+		return nil, fmt.Errorf("failed to initialize plugin %s: %v", name, err)
 	}
+	initDuration := time.Since(initStart)
+
+	return &openedPlugin{
+		handle:       p,
+		plugin:       dynamicPlugin,
+		openDuration: openDuration,
+		initDuration: initDuration,
+	}, nil
+}
+
+// installLocked wraps an opened plugin in an adapter, optionally registers it
+// with the tool registry, and records it as loaded. The caller must hold pm.mu.
+func (pm *PluginManager) installLocked(name string, pluginInfo PluginMetadata, pluginDir string, opened *openedPlugin, register bool) (*DynamicPluginAdapter, error) {
+	dynamicPlugin := opened.plugin
 
 	// Create adapter and register with registry
+	lifetime, endLifetime := context.WithCancelCause(context.Background())
 	adapter := &DynamicPluginAdapter{
-		plugin:   dynamicPlugin,
-		metadata: pluginInfo,
+		plugin:           dynamicPlugin,
+		metadata:         pluginInfo,
+		lifecycleTimeout: pm.lifecycleTimeout,
+		lifetime:         lifetime,
+		endLifetime:      endLifetime,
 	}
 
 	// Register with tool registry if provided
-	if pm.registry != nil {
+	if register && pm.registry != nil {
 		if err := pm.registry.RegisterTool(adapter); err != nil {
+			endLifetime(ErrPluginUnloaded)
 			// Clean up: shutdown the plugin since registration failed
-			dynamicPlugin.Shutdown()
-			return fmt.Errorf("failed to register plugin %s with registry: %v", name, err)
+			if err := CallWithTimeout(name+" Shutdown", pm.lifecycleTimeout, dynamicPlugin.Shutdown); err != nil {
+				slog.Warn("Failed to shut down plugin after registration failure", "plugin", name, "error", err)
+			}
+			return nil, fmt.Errorf("failed to register plugin %s with registry: %v", name, err)
 		}
 		slog.Info("Registered MCP tool", "name", name, "version", pluginInfo.Version, "description", pluginInfo.Description)
 	}
 
 	// Store the loaded plugin
 	pm.loaded[name] = adapter
-	slog.Info("Successfully loaded plugin", "name", name, "version", pluginInfo.Version)
+	pm.plugins[name] = &LoadedPlugin{
+		Metadata:  pluginInfo,
+		Plugin:    dynamicPlugin,
+		Handle:    opened.handle,
+		LoadedAt:  time.Now(),
+		Directory: pluginDir,
+		Enabled:   true,
+
+		OpenDuration: opened.openDuration,
+		InitDuration: opened.initDuration,
+	}
+	slog.Info("Successfully loaded plugin",
+		"name", name,
+		"version", pluginInfo.Version,
+		"open_duration", opened.openDuration,
+		"init_duration", opened.initDuration)
 
-	return nil
+	return adapter, nil
+}
+
+// openPluginFile opens a plugin file, retrying once if the first attempt fails
+func (pm *PluginManager) openPluginFile(path string) (*plugin.Plugin, error) {
+	p, err := pm.openWithTimeout(path)
+	if err == nil {
+		return p, nil
+	}
+
+	// A timed out open is still blocked inside the runtime loader, so a retry
+	// would only queue up behind it
+	if errors.Is(err, ErrPluginOpenTimeout) {
+		return nil, err
+	}
+
+	slog.Warn("Failed to open plugin, retrying", "path", path, "error", err)
+	time.Sleep(openRetryDelay)
+
+	return pm.openWithTimeout(path)
+}
+
+// openWithTimeout runs plugin.Open in a goroutine and gives up once the open timeout expires
+func (pm *PluginManager) openWithTimeout(path string) (*plugin.Plugin, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), pm.openTimeout)
+	defer cancel()
+
+	type openResult struct {
+		plugin *plugin.Plugin
+		err    error
+	}
+
+	// Buffered so the goroutine can finish even if nobody is waiting anymore
+	resultCh := make(chan openResult, 1)
+	go func() {
+		p, err := plugin.Open(path)
+		resultCh <- openResult{plugin: p, err: err}
+	}()
+
+	select {
+	case result := <-resultCh:
+		return result.plugin, result.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w after %s: %s", ErrPluginOpenTimeout, pm.openTimeout, path)
+	}
 }
 
 // UnloadPlugin unloads a specific plugin by name
 func (pm *PluginManager) UnloadPlugin(name string) error {
 	pm.mu.Lock()
-	defer pm.mu.Unlock()
-
 	loadedPlugin, exists := pm.loaded[name]
 	if !exists {
+		pm.mu.Unlock()
 		return fmt.Errorf("plugin %s not loaded", name)
 	}
 
-	// Unregister from tool registry first
+	// Forget the plugin up front and drain it outside the lock, so a plugin
+	// that ignores cancellation cannot stall the rest of the manager
+	delete(pm.loaded, name)
+	delete(pm.plugins, name)
+	pm.unloading[name] = true
+	pm.mu.Unlock()
+
+	// Signal running calls to stop before tearing the plugin down
+	loadedPlugin.endLifetime(ErrPluginUnloaded)
+
+	// Unregister from tool registry so no new calls reach it
 	if pm.registry != nil {
 		if err := pm.registry.UnregisterTool(name); err != nil {
 			slog.Warn("Failed to unregister plugin from registry", "plugin", name, "error", err)
@@ -210,32 +744,81 @@ func (pm *PluginManager) UnloadPlugin(name string) error {
 		}
 	}
 
-	// Shutdown the plugin
-	if err := loadedPlugin.plugin.Shutdown(); err != nil {
-		return fmt.Errorf("failed to shutdown plugin %s: %v", name, err)
+	// Wait for the cancelled calls to return, then shut the plugin down once.
+	// The plugin stays forgotten even if it fails to shut down so a stuck plugin cannot be retried forever
+	release, drained := loadedPlugin.drainCalls(pm.lifecycleTimeout)
+	if !drained {
+		slog.Warn("Plugin calls did not return after cancellation, shutting down anyway",
+			"plugin", name,
+			"active_calls", loadedPlugin.ActiveCalls())
 	}
+	shutdownErr := CallWithTimeout(name+" Shutdown", pm.lifecycleTimeout, loadedPlugin.plugin.Shutdown)
+	release()
 
-	// Remove from loaded plugins
-	delete(pm.loaded, name)
+	pm.mu.Lock()
+	delete(pm.unloading, name)
+	pm.lifecycleLocked(name).Unloads++
+	pm.mu.Unlock()
+
+	if shutdownErr != nil {
+		return fmt.Errorf("failed to shutdown plugin %s: %w", name, shutdownErr)
+	}
 	slog.Info("Successfully unloaded plugin", "plugin", name)
 
 	return nil
 }
 
-// ReloadPlugin reloads a plugin (unload then load)
+// ReloadPlugin reloads a plugin. A loaded plugin is re-initialized in place: new
+// calls wait while in-flight calls drain, then it is shut down and initialized
+// again, so the tool never disappears from clients. A plugin that is not loaded is loaded.
 func (pm *PluginManager) ReloadPlugin(name string) error {
-	// Check if plugin is loaded
 	pm.mu.RLock()
-	_, isLoaded := pm.plugins[name]
+	adapter, isLoaded := pm.loaded[name]
 	pm.mu.RUnlock()
 
-	if isLoaded {
+	if !isLoaded {
+		return pm.LoadPlugin(name)
+	}
+
+	// Block new calls and wait for in-flight ones to finish
+	adapter.gate.Lock()
+	slog.Info("Drained in-flight calls for reload", "plugin", name)
+
+	if err := CallWithTimeout(name+" Shutdown", pm.lifecycleTimeout, adapter.plugin.Shutdown); err != nil {
+		slog.Warn("Failed to shut down plugin for reload", "plugin", name, "error", err)
+	}
+
+	initStart := time.Now()
+	initErr := CallWithTimeout(name+" Initialize", pm.lifecycleTimeout, adapter.plugin.Initialize)
+	initDuration := time.Since(initStart)
+	adapter.gate.Unlock()
+
+	pm.mu.Lock()
+	stats := pm.lifecycleLocked(name)
+	stats.Reloads++
+	if initErr != nil {
+		stats.LastLoadError = initErr.Error()
+		stats.LastErrorAt = time.Now()
+	}
+	pm.mu.Unlock()
+
+	if initErr != nil {
+		// Remove the broken plugin so calls fail fast instead of reaching it
 		if err := pm.UnloadPlugin(name); err != nil {
-			return fmt.Errorf("failed to unload plugin for reload: %w", err)
+			slog.Warn("Failed to unload plugin after failed reload", "plugin", name, "error", err)
 		}
+		return fmt.Errorf("failed to reinitialize plugin %s: %w", name, initErr)
+	}
+
+	pm.mu.Lock()
+	if loadedPlugin, exists := pm.plugins[name]; exists {
+		loadedPlugin.LoadedAt = time.Now()
+		loadedPlugin.InitDuration = initDuration
 	}
+	pm.mu.Unlock()
 
-	return pm.LoadPlugin(name)
+	slog.Info("Successfully reloaded plugin", "name", name, "init_duration", initDuration)
+	return nil
 }
 
 // ListPlugins returns information about all discovered and loaded plugins
@@ -247,11 +830,22 @@ func (pm *PluginManager) ListPlugins() map[string]PluginStatus {
 
 	// Add all discovered plugins
 	for name, path := range pm.pluginPaths {
+		metadata := pm.discovered[name]
 		status := PluginStatus{
-			Name:       name,
-			Directory:  path,
-			Discovered: true,
-			Loaded:     false,
+			Name:        name,
+			Version:     metadata.Version,
+			Description: metadata.Description,
+			Author:      metadata.Author,
+			Directory:   path,
+			Discovered:  true,
+			Loaded:      false,
+			NotBuilt:    pm.notBuilt[name],
+			Deprecated:  metadata.Deprecated,
+			ReplacedBy:  metadata.ReplacedBy,
+		}
+
+		if stats, exists := pm.lifecycle[name]; exists {
+			status.Lifecycle = *stats
 		}
 
 		if loadedPlugin, exists := pm.plugins[name]; exists {
@@ -260,6 +854,18 @@ func (pm *PluginManager) ListPlugins() map[string]PluginStatus {
 			status.LoadedAt = loadedPlugin.LoadedAt
 			status.Version = loadedPlugin.Metadata.Version
 			status.Description = loadedPlugin.Metadata.Description
+			status.Author = loadedPlugin.Metadata.Author
+			status.OpenTimeMs = durationMs(loadedPlugin.OpenDuration)
+			status.InitTimeMs = durationMs(loadedPlugin.InitDuration)
+			status.LoadTimeMs = durationMs(loadedPlugin.LoadDuration())
+		}
+
+		if adapter, exists := pm.loaded[name]; exists {
+			status.Capabilities = adapter.Capabilities()
+			status.ActiveCalls = adapter.ActiveCalls()
+			if def := adapter.MCPToolDefinition(); def.Deprecated {
+				status.Deprecated, status.ReplacedBy = true, def.ReplacedBy
+			}
 		}
 
 		result[name] = status
@@ -279,19 +885,30 @@ func (pm *PluginManager) GetPlugin(name string) (*LoadedPlugin, bool) {
 
 // LoadAllPlugins loads all discovered plugins
 func (pm *PluginManager) LoadAllPlugins() error {
-	var errors []string
-
+	pm.mu.RLock()
+	names := make([]string, 0, len(pm.discovered))
 	for name := range pm.discovered {
-		if err := pm.LoadPlugin(name); err != nil {
-			errors = append(errors, fmt.Sprintf("plugin %s: %v", name, err))
+		if pm.notBuilt[name] {
+			slog.Info("Skipping plugin that is not built", "name", name, "path", pm.pluginPaths[name])
+			continue
 		}
+		names = append(names, name)
 	}
+	pm.mu.RUnlock()
 
-	if len(errors) > 0 {
-		return fmt.Errorf("failed to load some plugins: %s", strings.Join(errors, "; "))
-	}
+	return pm.LoadPlugins(names)
+}
 
-	return nil
+// DiscoveredPlugins returns the metadata of all discovered plugins
+func (pm *PluginManager) DiscoveredPlugins() map[string]PluginMetadata {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	result := make(map[string]PluginMetadata, len(pm.discovered))
+	for name, metadata := range pm.discovered {
+		result[name] = metadata
+	}
+	return result
 }
 
 // PluginStatus represents the status of a plugin
@@ -299,16 +916,37 @@ type PluginStatus struct {
 	Name        string    `json:"name"`
 	Version     string    `json:"version"`
 	Description string    `json:"description"`
+	Author      string    `json:"author,omitempty"`
 	Directory   string    `json:"directory"`
 	Discovered  bool      `json:"discovered"`
 	Loaded      bool      `json:"loaded"`
+	NotBuilt    bool      `json:"not_built,omitempty"` // discovered but has no compiled library
+	Deprecated  bool      `json:"deprecated,omitempty"`
+	ReplacedBy  string    `json:"replaced_by,omitempty"`
 	Enabled     bool      `json:"enabled"`
 	LoadedAt    time.Time `json:"loaded_at,omitempty"`
+
+	// Load timings in milliseconds, set once the plugin is loaded
+	OpenTimeMs float64 `json:"open_time_ms,omitempty"`
+	InitTimeMs float64 `json:"init_time_ms,omitempty"`
+	LoadTimeMs float64 `json:"load_time_ms,omitempty"`
+
+	// Calls currently executing in the plugin
+	ActiveCalls int64 `json:"active_calls"`
+
+	Lifecycle PluginLifecycleStats `json:"lifecycle"`
+
+	Capabilities map[string]interface{} `json:"capabilities,omitempty"`
+}
+
+// durationMs converts a duration to fractional milliseconds
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
 }
 
 // loadMetadata loads plugin metadata from plugin.json
 func (pm *PluginManager) loadMetadata(path string) (PluginMetadata, error) {
-	var metadata PluginMetadata
+	metadata := PluginMetadata{EnabledDefault: true}
 
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -335,8 +973,18 @@ func (pm *PluginManager) loadMetadata(path string) (PluginMetadata, error) {
 
 // DynamicPluginAdapter adapts DynamicPlugin to MCPToolPlugin interface
 type DynamicPluginAdapter struct {
-	plugin   DynamicPlugin
-	metadata PluginMetadata
+	plugin           DynamicPlugin
+	metadata         PluginMetadata
+	lifecycleTimeout time.Duration
+
+	// Calls hold gate for reading; a reload takes it for writing to drain them
+	gate        sync.RWMutex
+	activeCalls atomic.Int64
+
+	// lifetime spans from load to unload; every call's context derives from it
+	// so unloading cancels calls that are still running
+	lifetime    context.Context
+	endLifetime context.CancelCauseFunc
 }
 
 func (dpa *DynamicPluginAdapter) Name() string {
@@ -351,23 +999,163 @@ func (dpa *DynamicPluginAdapter) Description() string {
 	return dpa.plugin.Description()
 }
 
+// Metadata returns the plugin.json metadata the plugin was loaded with
+func (dpa *DynamicPluginAdapter) Metadata() PluginMetadata {
+	return dpa.metadata
+}
+
 func (dpa *DynamicPluginAdapter) MCPToolDefinition() MCPTool {
-	return dpa.plugin.MCPToolDefinition()
+	return withDeprecation(dpa.plugin.MCPToolDefinition(), dpa.metadata)
+}
+
+// withDeprecation applies a deprecation declared in plugin.json to a tool
+// definition that does not declare one itself
+func withDeprecation(def MCPTool, metadata PluginMetadata) MCPTool {
+	if metadata.Deprecated && !def.Deprecated {
+		def.Deprecated = true
+		def.ReplacedBy = metadata.ReplacedBy
+	}
+	return def
 }
 
 func (dpa *DynamicPluginAdapter) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
-	return dpa.plugin.Execute(ctx, args)
+	dpa.gate.RLock()
+	defer dpa.gate.RUnlock()
+
+	// A caller may still hold the tool after it was unloaded and shut down
+	if err := context.Cause(dpa.lifetime); err != nil {
+		return nil, fmt.Errorf("plugin %s: %w", dpa.plugin.Name(), err)
+	}
+
+	dpa.activeCalls.Add(1)
+	defer dpa.activeCalls.Add(-1)
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stop := context.AfterFunc(dpa.lifetime, func() {
+		cancel(context.Cause(dpa.lifetime))
+	})
+	defer stop()
+
+	result, err := dpa.plugin.Execute(ctx, args)
+	if err != nil && errors.Is(context.Cause(ctx), ErrPluginUnloaded) {
+		err = fmt.Errorf("%w during call: %w", ErrPluginUnloaded, err)
+	}
+	return result, err
+}
+
+// drainCalls blocks new calls and waits up to timeout for in-flight calls to
+// return. release lifts the block; if the wait timed out it takes effect once
+// the stragglers finish.
+func (dpa *DynamicPluginAdapter) drainCalls(timeout time.Duration) (release func(), drained bool) {
+	if timeout <= 0 {
+		timeout = DefaultLifecycleTimeout
+	}
+
+	locked := make(chan struct{})
+	released := make(chan struct{})
+	go func() {
+		dpa.gate.Lock()
+		close(locked)
+		<-released
+		dpa.gate.Unlock()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-locked:
+		drained = true
+	case <-timer.C:
+	}
+	return func() { close(released) }, drained
+}
+
+// ActiveCalls returns the number of calls currently executing in the plugin
+func (dpa *DynamicPluginAdapter) ActiveCalls() int64 {
+	return dpa.activeCalls.Load()
 }
 
 func (dpa *DynamicPluginAdapter) InputSchema() map[string]interface{} {
 	return dpa.plugin.InputSchema()
 }
 
+// Capabilities returns the plugin's advertised capabilities, or nil if it does not provide any
+func (dpa *DynamicPluginAdapter) Capabilities() map[string]interface{} {
+	if provider, ok := dpa.plugin.(CapabilitiesProvider); ok {
+		return provider.Capabilities()
+	}
+	return nil
+}
+
+// Prompts returns the prompts the plugin exposes, or nil if it exposes none
+func (dpa *DynamicPluginAdapter) Prompts() []Prompt {
+	if provider, ok := dpa.plugin.(PromptProvider); ok {
+		return provider.Prompts()
+	}
+	return nil
+}
+
+// GetPrompt renders one of the plugin's prompts, waiting out a reload in progress
+func (dpa *DynamicPluginAdapter) GetPrompt(ctx context.Context, name string, args map[string]string) ([]PromptMessage, error) {
+	provider, ok := dpa.plugin.(PromptProvider)
+	if !ok {
+		return nil, fmt.Errorf("plugin %s does not provide prompts", dpa.plugin.Name())
+	}
+
+	dpa.gate.RLock()
+	defer dpa.gate.RUnlock()
+	return provider.GetPrompt(ctx, name, args)
+}
+
+// Resources returns the resources the plugin exposes, or nil if it exposes none
+func (dpa *DynamicPluginAdapter) Resources() []Resource {
+	if provider, ok := dpa.plugin.(ResourceProvider); ok {
+		return provider.Resources()
+	}
+	return nil
+}
+
+// ReadResource reads one of the plugin's resources, waiting out a reload in progress
+func (dpa *DynamicPluginAdapter) ReadResource(ctx context.Context, uri string) ([]ResourceContent, error) {
+	provider, ok := dpa.plugin.(ResourceProvider)
+	if !ok {
+		return nil, fmt.Errorf("plugin %s does not provide resources", dpa.plugin.Name())
+	}
+
+	dpa.gate.RLock()
+	defer dpa.gate.RUnlock()
+	return provider.ReadResource(ctx, uri)
+}
+
+// SupportsDryRun reports whether the wrapped plugin accepts dry-run calls
+func (dpa *DynamicPluginAdapter) SupportsDryRun() bool {
+	if runner, ok := dpa.plugin.(DryRunner); ok {
+		return runner.SupportsDryRun()
+	}
+	return false
+}
+
 func (dpa *DynamicPluginAdapter) Initialize() error {
 	// Plugin is already initialized during loading, so this is a no-op
 	return nil
 }
 
+// Reinitialize runs the plugin's Initialize again, waiting for in-flight calls
+// first. An unloaded plugin is never brought back.
+func (dpa *DynamicPluginAdapter) Reinitialize() error {
+	dpa.gate.Lock()
+	defer dpa.gate.Unlock()
+
+	if err := context.Cause(dpa.lifetime); err != nil {
+		return fmt.Errorf("plugin %s: %w", dpa.plugin.Name(), err)
+	}
+
+	return CallWithTimeout(dpa.plugin.Name()+" Initialize", dpa.lifecycleTimeout, dpa.plugin.Initialize)
+}
+
 func (dpa *DynamicPluginAdapter) Cleanup() error {
-	return dpa.plugin.Shutdown()
+	// The manager shuts the plugin down when it unloads it, once in-flight calls have drained
+	return nil
 }
@@ -0,0 +1,53 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writePluginDir creates a plugin directory holding only a plugin.json
+func writePluginDir(t *testing.T, baseDir, name string) {
+	t.Helper()
+
+	dir := filepath.Join(baseDir, name)
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	metadata := fmt.Sprintf(`{"name": %q, "version": "1.0.0", "entry_point": "NewPlugin"}`, name)
+	if err := os.WriteFile(filepath.Join(dir, "plugin.json"), []byte(metadata), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestDiscoverPluginsLimitIgnoresRemovedPlugins checks that plugins removed
+// since an earlier scan do not use up the plugin limit
+func TestDiscoverPluginsLimitIgnoresRemovedPlugins(t *testing.T) {
+	baseDir := t.TempDir()
+	writePluginDir(t, baseDir, "a")
+	writePluginDir(t, baseDir, "b")
+
+	pm := NewPluginManagerWithOptions(baseDir, nil, &PluginManagerOptions{MaxPlugins: 2})
+	if err := pm.DiscoverPlugins(); err != nil {
+		t.Fatalf("first DiscoverPlugins: %v", err)
+	}
+
+	if err := os.RemoveAll(filepath.Join(baseDir, "a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(baseDir, "b")); err != nil {
+		t.Fatal(err)
+	}
+	writePluginDir(t, baseDir, "c")
+	writePluginDir(t, baseDir, "d")
+
+	if err := pm.DiscoverPlugins(); err != nil {
+		t.Fatalf("second DiscoverPlugins: %v", err)
+	}
+	for _, entry := range pm.DiscoveryReport().Entries {
+		if entry.Status != DiscoveryIncluded {
+			t.Errorf("%s skipped: %s", entry.Path, entry.Reason)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// LazyPlugin is a lightweight tool stub built from plugin.json metadata. The
+// plugin itself is opened and initialized the first time the tool is called.
+type LazyPlugin struct {
+	manager  *PluginManager
+	metadata PluginMetadata
+}
+
+// RegisterLazyPlugins registers stubs for the named plugins with the tool registry
+// without opening them
+func (pm *PluginManager) RegisterLazyPlugins(names []string) error {
+	if pm.registry == nil {
+		return fmt.Errorf("lazy loading requires a tool registry")
+	}
+
+	var errors []string
+	for _, name := range names {
+		pm.mu.RLock()
+		metadata, exists := pm.discovered[name]
+		pm.mu.RUnlock()
+
+		if !exists {
+			errors = append(errors, fmt.Sprintf("plugin %s: not found", name))
+			continue
+		}
+
+		stub := &LazyPlugin{manager: pm, metadata: metadata}
+		if err := pm.registry.RegisterTool(stub); err != nil {
+			errors = append(errors, fmt.Sprintf("plugin %s: %v", name, err))
+		}
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("failed to register some lazy plugins: %s", strings.Join(errors, "; "))
+	}
+
+	return nil
+}
+
+// ensureLoaded returns the adapter for a plugin, loading it without registration
+// if needed. Callers that find the plugin already loading wait for that attempt.
+func (pm *PluginManager) ensureLoaded(name string) (*DynamicPluginAdapter, error) {
+	pm.mu.Lock()
+	for {
+		if adapter, exists := pm.loaded[name]; exists {
+			pm.mu.Unlock()
+			return adapter, nil
+		}
+
+		pending := pm.loading[name]
+		if pending == nil {
+			break
+		}
+		pm.mu.Unlock()
+		<-pending.done
+		if pending.err != nil {
+			return nil, pending.err
+		}
+		pm.mu.Lock()
+	}
+
+	pluginInfo, pluginDir, err := pm.prepareLoadLocked(name)
+	if err != nil {
+		pm.mu.Unlock()
+		return nil, err
+	}
+	pending := pm.beginLoadLocked(name)
+	pm.mu.Unlock()
+
+	return pm.completeLoad(name, pluginInfo, pluginDir, pending, false)
+}
+
+func (lp *LazyPlugin) Name() string {
+	return lp.metadata.Name
+}
+
+func (lp *LazyPlugin) Version() string {
+	return lp.metadata.Version
+}
+
+func (lp *LazyPlugin) Description() string {
+	return lp.metadata.Description
+}
+
+// InputSchema returns the schema declared in plugin.json, or an open object schema if none is declared
+func (lp *LazyPlugin) InputSchema() map[string]interface{} {
+	if lp.metadata.InputSchema != nil {
+		return lp.metadata.InputSchema
+	}
+	return map[string]interface{}{"type": "object"}
+}
+
+func (lp *LazyPlugin) MCPToolDefinition() MCPTool {
+	return withDeprecation(MCPTool{
+		Name:        lp.Name(),
+		Description: lp.Description(),
+		InputSchema: lp.InputSchema(),
+	}, lp.metadata)
+}
+
+// Execute loads the plugin on first use and delegates the call to it
+func (lp *LazyPlugin) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	adapter, err := lp.manager.ensureLoaded(lp.metadata.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load plugin on demand: %w", err)
+	}
+	return adapter.Execute(ctx, args)
+}
+
+// SupportsDryRun loads the plugin to find out whether it accepts dry-run calls
+func (lp *LazyPlugin) SupportsDryRun() bool {
+	adapter, err := lp.manager.ensureLoaded(lp.metadata.Name)
+	if err != nil {
+		return false
+	}
+	return adapter.SupportsDryRun()
+}
+
+// Reinitialize reinitializes the loaded plugin, loading it first if needed
+func (lp *LazyPlugin) Reinitialize() error {
+	adapter, err := lp.manager.ensureLoaded(lp.metadata.Name)
+	if err != nil {
+		return fmt.Errorf("failed to load plugin on demand: %w", err)
+	}
+	return adapter.Reinitialize()
+}
+
+func (lp *LazyPlugin) Initialize() error {
+	// Nothing to do until the plugin is first called
+	return nil
+}
+
+func (lp *LazyPlugin) Cleanup() error {
+	// A loaded plugin is shut down by the manager when it is unloaded
+	return nil
+}
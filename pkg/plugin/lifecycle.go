@@ -0,0 +1,39 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultLifecycleTimeout bounds Initialize and Shutdown calls when no timeout is configured
+const DefaultLifecycleTimeout = 10 * time.Second
+
+// ErrLifecycleTimeout is returned when a plugin's Initialize or Shutdown does not return in time
+var ErrLifecycleTimeout = errors.New("plugin lifecycle call timed out")
+
+// CallWithTimeout runs a lifecycle method such as Initialize or Shutdown and gives
+// up once timeout expires. A call that times out keeps running in the background,
+// since plugin code cannot be interrupted; the caller should treat the plugin as failed.
+// A non-positive timeout uses DefaultLifecycleTimeout.
+func CallWithTimeout(operation string, timeout time.Duration, fn func() error) error {
+	if timeout <= 0 {
+		timeout = DefaultLifecycleTimeout
+	}
+
+	// Buffered so the goroutine can finish even if nobody is waiting anymore
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fn()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("%w: %s did not return within %s", ErrLifecycleTimeout, operation, timeout)
+	}
+}
@@ -0,0 +1,192 @@
+package plugin
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLoadConcurrency is how many plugins LoadPlugins opens at once when no
+// concurrency is configured
+const DefaultLoadConcurrency = 4
+
+// LoadErrors reports the plugins that failed to load, keyed by name
+type LoadErrors map[string]error
+
+// Error lists each failed plugin in name order
+func (e LoadErrors) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	messages := make([]string, 0, len(names))
+	for _, name := range names {
+		messages = append(messages, fmt.Sprintf("plugin %s: %v", name, e[name]))
+	}
+	return "failed to load some plugins: " + strings.Join(messages, "; ")
+}
+
+// LoadPlugins loads the named plugins, continuing past failures and reporting
+// them together as LoadErrors. Plugins are loaded in dependency layers: each
+// layer is opened by a bounded pool of workers once every plugin it depends on
+// has loaded. A plugin whose dependency fails is not loaded.
+func (pm *PluginManager) LoadPlugins(names []string) error {
+	layers, failed := pm.loadLayers(names)
+
+	for _, layer := range layers {
+		var ready []string
+		for _, name := range layer {
+			if dep := pm.failedDependency(name, failed); dep != "" {
+				failed[name] = fmt.Errorf("dependency %s failed to load", dep)
+				continue
+			}
+			ready = append(ready, name)
+		}
+
+		for name, err := range pm.loadConcurrently(ready) {
+			failed[name] = err
+		}
+	}
+
+	if len(failed) > 0 {
+		return failed
+	}
+	return nil
+}
+
+// loadConcurrently loads the plugins with at most loadConcurrency at a time
+func (pm *PluginManager) loadConcurrently(names []string) LoadErrors {
+	var mu sync.Mutex
+	failed := make(LoadErrors)
+
+	work := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < min(pm.loadConcurrency, len(names)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range work {
+				if err := pm.LoadPlugin(name); err != nil {
+					mu.Lock()
+					failed[name] = err
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, name := range names {
+		work <- name
+	}
+	close(work)
+	wg.Wait()
+
+	return failed
+}
+
+// loadLayers groups the plugins so each layer only depends on earlier layers
+// or plugins that are already loaded. Plugins whose dependencies cannot be
+// satisfied are returned as failures instead.
+func (pm *PluginManager) loadLayers(names []string) ([][]string, LoadErrors) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	failed := make(LoadErrors)
+	pending := make(map[string][]string, len(names)) // name -> dependencies still to load
+	for _, name := range names {
+		pending[name] = nil
+	}
+
+	for _, name := range names {
+		for _, dep := range pm.discovered[name].Dependencies {
+			switch {
+			case pm.loaded[dep] != nil:
+				// Already available
+			case hasKey(pending, dep):
+				pending[name] = append(pending[name], dep)
+			default:
+				failed[name] = fmt.Errorf("dependency %s is not loaded", dep)
+			}
+		}
+	}
+
+	// Drop plugins that depend, directly or not, on one that cannot load
+	for changed := true; changed; {
+		changed = false
+		for name, deps := range pending {
+			if _, didFail := failed[name]; didFail {
+				delete(pending, name)
+				changed = true
+				continue
+			}
+			for _, dep := range deps {
+				if _, didFail := failed[dep]; didFail {
+					failed[name] = fmt.Errorf("dependency %s cannot be loaded", dep)
+					delete(pending, name)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	var layers [][]string
+	placed := make(map[string]bool)
+	for len(pending) > 0 {
+		var layer []string
+		for name, deps := range pending {
+			if allPlaced(deps, placed) {
+				layer = append(layer, name)
+			}
+		}
+
+		// Whatever remains is part of or waiting on a dependency cycle
+		if len(layer) == 0 {
+			for name, deps := range pending {
+				failed[name] = fmt.Errorf("dependency cycle through %s", strings.Join(deps, ", "))
+			}
+			break
+		}
+
+		sort.Strings(layer)
+		for _, name := range layer {
+			placed[name] = true
+			delete(pending, name)
+		}
+		layers = append(layers, layer)
+	}
+
+	return layers, failed
+}
+
+// failedDependency returns a dependency of the plugin that failed to load
+func (pm *PluginManager) failedDependency(name string, failed LoadErrors) string {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	for _, dep := range pm.discovered[name].Dependencies {
+		if _, didFail := failed[dep]; didFail {
+			return dep
+		}
+	}
+	return ""
+}
+
+// hasKey reports whether the map holds the key
+func hasKey(m map[string][]string, key string) bool {
+	_, exists := m[key]
+	return exists
+}
+
+// allPlaced reports whether every dependency is in an earlier layer
+func allPlaced(deps []string, placed map[string]bool) bool {
+	for _, dep := range deps {
+		if !placed[dep] {
+			return false
+		}
+	}
+	return true
+}
@@ -2,6 +2,7 @@ package plugin
 
 import (
 	"context"
+	"errors"
 )
 
 // MCPTool represents an MCP tool definition for the protocol
@@ -9,6 +10,11 @@ type MCPTool struct {
 	Name        string                 `json:"name"`
 	Description string                 `json:"description"`
 	InputSchema map[string]interface{} `json:"inputSchema"`
+
+	// Deprecated tools keep working but clients are told to migrate, to
+	// ReplacedBy when it names a successor
+	Deprecated bool   `json:"deprecated,omitempty"`
+	ReplacedBy string `json:"replaced_by,omitempty"`
 }
 
 // MCPToolPlugin defines the interface for MCP tool plugins
@@ -29,6 +35,61 @@ type MCPToolPlugin interface {
 	Cleanup() error
 }
 
+// ErrInvalidArguments is wrapped by tools to report bad input, which clients
+// receive as a JSON-RPC invalid params error:
+//
+//	return nil, fmt.Errorf("%w: path is required", plugin.ErrInvalidArguments)
+var ErrInvalidArguments = errors.New("invalid arguments")
+
+// ErrNotInitialized is returned by tools called before Initialize or after Shutdown.
+// The server reinitializes the plugin once and retries the call when it sees it.
+var ErrNotInitialized = errors.New("plugin not initialized")
+
+// Reinitializer is implemented by tools that can re-run their plugin's Initialize
+// to recover from ErrNotInitialized
+type Reinitializer interface {
+	Reinitialize() error
+}
+
+// IsNotInitialized reports whether err wraps ErrNotInitialized
+func IsNotInitialized(err error) bool {
+	return errors.Is(err, ErrNotInitialized)
+}
+
+// DryRunArg is the meta-argument clients set to true to pre-flight a tool call
+const DryRunArg = "_dry_run"
+
+// PrettyArg is the meta-argument clients set to override pretty-printing of JSON results.
+// The server consumes it; tools never see it.
+const PrettyArg = "_pretty"
+
+// ResourceResult is returned by a tool to have the server publish its output as an
+// MCP resource and reply with a reference to it instead of inline content
+type ResourceResult struct {
+	Text     string
+	MIMEType string // defaults to text/plain
+	Name     string // human-readable resource name, defaults to "<tool> result"
+}
+
+// DryRunner is optionally implemented by tools that accept DryRunArg. When it is
+// set, such tools validate their input and report what they would do without
+// performing any side effects.
+type DryRunner interface {
+	SupportsDryRun() bool
+}
+
+// IsDryRun reports whether the arguments request a dry run
+func IsDryRun(args map[string]interface{}) bool {
+	dryRun, _ := args[DryRunArg].(bool)
+	return dryRun
+}
+
+// RegisterCallback is invoked after a tool has been registered
+type RegisterCallback func(tool MCPToolPlugin)
+
+// UnregisterCallback is invoked after a tool has been unregistered
+type UnregisterCallback func(name string)
+
 // ToolRegistry manages MCP tool plugins
 type ToolRegistry interface {
 	// RegisterTool adds a tool to the registry
@@ -46,6 +107,12 @@ type ToolRegistry interface {
 	// DiscoverTools scans for available tools
 	DiscoverTools() error
 
+	// OnRegister subscribes to tool registrations
+	OnRegister(callback RegisterCallback)
+
+	// OnUnregister subscribes to tool unregistrations
+	OnUnregister(callback UnregisterCallback)
+
 	// Lifecycle
 	Shutdown() error
 }
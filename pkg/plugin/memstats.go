@@ -0,0 +1,41 @@
+package plugin
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// DefaultMemStatsTTL is how long a memory statistics snapshot is reused by default
+const DefaultMemStatsTTL = time.Second
+
+// memStatsCache shares one runtime.MemStats snapshot between the host and
+// plugins. ReadMemStats stops the world, so frequent metric scrapes and
+// systeminfo calls reuse a recent snapshot instead of each triggering a read.
+var memStatsCache = struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	stats  runtime.MemStats
+	readAt time.Time // zero until the first read
+}{ttl: DefaultMemStatsTTL}
+
+// SetMemStatsTTL sets how long a snapshot is reused; 0 reads fresh statistics every time
+func SetMemStatsTTL(ttl time.Duration) {
+	memStatsCache.mu.Lock()
+	defer memStatsCache.mu.Unlock()
+	memStatsCache.ttl = ttl
+}
+
+// ReadMemStats returns runtime memory statistics no older than the configured
+// TTL, along with the snapshot's age
+func ReadMemStats() (runtime.MemStats, time.Duration) {
+	memStatsCache.mu.Lock()
+	defer memStatsCache.mu.Unlock()
+
+	now := time.Now()
+	if memStatsCache.readAt.IsZero() || now.Sub(memStatsCache.readAt) >= memStatsCache.ttl {
+		runtime.ReadMemStats(&memStatsCache.stats)
+		memStatsCache.readAt = now
+	}
+	return memStatsCache.stats, now.Sub(memStatsCache.readAt)
+}
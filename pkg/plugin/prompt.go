@@ -0,0 +1,39 @@
+package plugin
+
+import "context"
+
+// PromptArgument is an argument a prompt template accepts
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// Prompt is a reusable prompt template a plugin exposes to MCP clients
+type Prompt struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+}
+
+// Roles of a prompt message
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// PromptMessage is one message of a rendered prompt
+type PromptMessage struct {
+	Role string `json:"role"` // RoleUser or RoleAssistant
+	Text string `json:"text"`
+}
+
+// PromptProvider is optionally implemented by a DynamicPlugin to expose MCP
+// prompts alongside its tool. Prompt names share one namespace across plugins,
+// so prefix them with the plugin name. Required arguments are checked before
+// GetPrompt is called. Plugins registered lazily expose no prompts, since
+// they are not opened until their tool is called.
+type PromptProvider interface {
+	Prompts() []Prompt
+	GetPrompt(ctx context.Context, name string, args map[string]string) ([]PromptMessage, error)
+}
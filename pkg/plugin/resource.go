@@ -0,0 +1,31 @@
+package plugin
+
+import "context"
+
+// Resource is a readable URI a plugin exposes to MCP clients. Set URITemplate
+// instead of URI to expose a family of resources, such as file:///{+path}.
+type Resource struct {
+	URI         string `json:"uri,omitempty"`
+	URITemplate string `json:"uri_template,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MIMEType    string `json:"mime_type,omitempty"`
+}
+
+// ResourceContent is the content of a resource that was read. Binary content
+// goes in Blob, which takes precedence over Text.
+type ResourceContent struct {
+	URI      string `json:"uri"`
+	MIMEType string `json:"mime_type,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     []byte `json:"blob,omitempty"`
+}
+
+// ResourceProvider is optionally implemented by a DynamicPlugin to expose MCP
+// resources alongside its tool. ReadResource receives the URI the client asked
+// for, which for a template is the expanded URI. Plugins registered lazily
+// expose no resources, since they are not opened until their tool is called.
+type ResourceProvider interface {
+	Resources() []Resource
+	ReadResource(ctx context.Context, uri string) ([]ResourceContent, error)
+}
@@ -0,0 +1,90 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ContentType identifies the kind of a ToolResult content item
+type ContentType string
+
+const (
+	// ContentText is plain text returned as-is
+	ContentText ContentType = "text"
+
+	// ContentJSON is a value the server encodes as JSON
+	ContentJSON ContentType = "json"
+
+	// ContentImage is binary image data with a MIME type
+	ContentImage ContentType = "image"
+)
+
+// ContentItem is a single piece of tool output
+type ContentItem struct {
+	Type     ContentType `json:"type"`
+	Text     string      `json:"text,omitempty"`      // ContentText
+	Data     interface{} `json:"data,omitempty"`      // ContentJSON
+	Blob     []byte      `json:"blob,omitempty"`      // ContentImage
+	MIMEType string      `json:"mime_type,omitempty"` // ContentImage
+}
+
+// ToolResult is the typed result a tool can return from Execute instead of a bare
+// value. Plain values are still accepted and formatted by the server.
+type ToolResult struct {
+	Content []ContentItem `json:"content"`
+
+	// IsError marks the result as a failed call. ErrorCode is the JSON-RPC error
+	// code reported to the client; zero means an internal error.
+	IsError   bool `json:"is_error,omitempty"`
+	ErrorCode int  `json:"error_code,omitempty"`
+}
+
+// TextResult returns a result with a single text item
+func TextResult(text string) *ToolResult {
+	return &ToolResult{Content: []ContentItem{{Type: ContentText, Text: text}}}
+}
+
+// JSONResult returns a result with a single item the server encodes as JSON
+func JSONResult(data interface{}) *ToolResult {
+	return &ToolResult{Content: []ContentItem{{Type: ContentJSON, Data: data}}}
+}
+
+// ErrorResult returns a failed result with the given JSON-RPC error code and message
+func ErrorResult(code int, message string) *ToolResult {
+	return &ToolResult{
+		Content:   []ContentItem{{Type: ContentText, Text: message}},
+		IsError:   true,
+		ErrorCode: code,
+	}
+}
+
+// Err returns the failure described by an error result, or nil if the call succeeded
+func (r *ToolResult) Err() error {
+	if r == nil || !r.IsError {
+		return nil
+	}
+
+	var messages []string
+	for _, item := range r.Content {
+		if item.Type == ContentText && item.Text != "" {
+			messages = append(messages, item.Text)
+		}
+	}
+	message := strings.Join(messages, "; ")
+	if message == "" {
+		message = "tool reported an error"
+	}
+
+	return &ToolError{Code: r.ErrorCode, Message: message}
+}
+
+// ToolError is an error carrying the JSON-RPC error code to report to the client.
+// Tools can return it directly from Execute.
+type ToolError struct {
+	Code    int
+	Message string
+}
+
+func (e *ToolError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
@@ -0,0 +1,10 @@
+// Package redact holds the argument key patterns that are masked by default
+// wherever tool arguments are logged or recorded. It has no dependencies so
+// both the configuration and the MCP server can share it.
+package redact
+
+// DefaultPatterns are the case-insensitive argument key patterns redacted
+// when none are configured. They match anywhere in a key, so secret_key and
+// auth_token_id are masked; only plural token counts such as max_tokens are
+// left out.
+var DefaultPatterns = []string{"password", "secret", "token($|[^s])", "api[_-]?key"}
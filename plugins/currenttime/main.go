@@ -2,19 +2,56 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/eadydb/zephyr/pkg/plugin"
 )
 
+// ErrNoTZData means no time zone database is available on the host
+var ErrNoTZData = errors.New("no tzdata available")
+
+// tzdataProbeZone exists in every time zone database, so failing to load it
+// means there is no database at all
+const tzdataProbeZone = "Etc/GMT"
+
+var (
+	tzdataOnce      sync.Once
+	tzdataAvailable bool
+)
+
+// hasTZData reports whether a time zone database can be loaded
+func hasTZData() bool {
+	tzdataOnce.Do(func() {
+		_, err := time.LoadLocation(tzdataProbeZone)
+		tzdataAvailable = err == nil
+	})
+	return tzdataAvailable
+}
+
+// loadLocation resolves a timezone, telling an unknown zone apart from a host
+// that has no time zone database
+func loadLocation(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err == nil {
+		return loc, nil
+	}
+	if !hasTZData() {
+		return nil, fmt.Errorf("%w to resolve timezone %s: install the host zoneinfo package or build zephyr with -tags tzdata",
+			ErrNoTZData, name)
+	}
+	return nil, fmt.Errorf("%w: unknown timezone %s", plugin.ErrInvalidArguments, name)
+}
+
 // Plugin is the exported plugin instance
 var Plugin plugin.DynamicPlugin = &CurrentTimePlugin{}
 
 // CurrentTimePlugin implements the DynamicPlugin interface
 type CurrentTimePlugin struct {
-	initialized bool
+	initialized atomic.Bool // read by Execute while a reload runs Initialize or Shutdown
 }
 
 // NewPlugin is the factory function that will be called by the plugin loader
@@ -39,16 +76,15 @@ func (p *CurrentTimePlugin) Description() string {
 
 // Initialize initializes the plugin
 func (p *CurrentTimePlugin) Initialize() error {
-	if p.initialized {
+	if !p.initialized.CompareAndSwap(false, true) {
 		return fmt.Errorf("plugin already initialized")
 	}
-	p.initialized = true
 	return nil
 }
 
 // Shutdown cleans up the plugin
 func (p *CurrentTimePlugin) Shutdown() error {
-	p.initialized = false
+	p.initialized.Store(false)
 	return nil
 }
 
@@ -87,31 +123,22 @@ func (p *CurrentTimePlugin) InputSchema() map[string]interface{} {
 
 // Execute executes the tool with the given arguments
 func (p *CurrentTimePlugin) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
-	if !p.initialized {
-		return nil, fmt.Errorf("plugin not initialized")
+	if !p.initialized.Load() {
+		return nil, plugin.ErrNotInitialized
 	}
 
 	// Parse arguments
-	timezone := "UTC"
-	format := "rfc3339"
-	includeUTC := true
-
-	if tz, exists := args["timezone"]; exists {
-		if t, ok := tz.(string); ok {
-			timezone = t
-		}
+	timezone, err := plugin.StringArg(args, "timezone", "UTC")
+	if err != nil {
+		return nil, err
 	}
-
-	if fmt, exists := args["format"]; exists {
-		if f, ok := fmt.(string); ok {
-			format = f
-		}
+	format, err := plugin.StringArg(args, "format", "rfc3339")
+	if err != nil {
+		return nil, err
 	}
-
-	if inc, exists := args["include_utc"]; exists {
-		if i, ok := inc.(bool); ok {
-			includeUTC = i
-		}
+	includeUTC, err := plugin.BoolArg(args, "include_utc", true)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get current time
@@ -119,13 +146,12 @@ func (p *CurrentTimePlugin) Execute(ctx context.Context, args map[string]interfa
 
 	// Load timezone
 	var loc *time.Location
-	var err error
 	if timezone == "UTC" {
 		loc = time.UTC
 	} else {
-		loc, err = time.LoadLocation(timezone)
+		loc, err = loadLocation(timezone)
 		if err != nil {
-			return nil, fmt.Errorf("invalid timezone %s: %w", timezone, err)
+			return nil, err
 		}
 	}
 
@@ -179,13 +205,7 @@ func (p *CurrentTimePlugin) Execute(ctx context.Context, args map[string]interfa
 		}
 	}
 
-	// Return as JSON string for consistent output
-	jsonBytes, err := json.Marshal(result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal time info: %w", err)
-	}
-
-	return string(jsonBytes), nil
+	return plugin.JSONResult(result), nil
 }
 
 // main function is required for plugin compilation but won't be used
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// directoryID returns a stable identity for a directory. Without inode numbers
+// the cleaned, fully resolved path stands in for it.
+func (p *FileOpsPlugin) directoryID(path string) (string, error) {
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	return resolvedDirectoryID(path)
+}
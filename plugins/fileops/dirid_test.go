@@ -0,0 +1,35 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestResolvedDirectoryIDFollowsSymlinks checks that the path-based fallback
+// gives a directory and a symlink to it the same identity
+func TestResolvedDirectoryIDFollowsSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target")
+	if err := os.Mkdir(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	want, err := resolvedDirectoryID(target)
+	if err != nil {
+		t.Fatalf("resolvedDirectoryID(target): %v", err)
+	}
+	for _, path := range []string{link, link + "/", filepath.Join(target, "..", "target")} {
+		got, err := resolvedDirectoryID(path)
+		if err != nil {
+			t.Fatalf("resolvedDirectoryID(%q): %v", path, err)
+		}
+		if got != want {
+			t.Errorf("resolvedDirectoryID(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
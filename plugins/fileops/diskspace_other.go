@@ -0,0 +1,9 @@
+//go:build !unix
+
+package main
+
+// checkFreeSpace is a no-op where free space cannot be queried; the write
+// itself still fails if the disk fills up.
+func checkFreeSpace(path string, size int64, atomic bool) error {
+	return nil
+}
@@ -0,0 +1,44 @@
+//go:build unix
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// checkFreeSpace fails if the filesystem that will hold path lacks room for size
+// bytes. A plain overwrite truncates the old file first, so its size counts as
+// free; an atomic write needs room for both copies until the rename.
+func checkFreeSpace(path string, size int64, atomic bool) error {
+	// Stat the nearest existing ancestor, since parents may not be created yet
+	dir := filepath.Dir(path)
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return fmt.Errorf("failed to check free space: %w", err)
+	}
+	available := int64(stat.Bavail) * int64(stat.Bsize)
+
+	needed := size
+	if info, err := os.Stat(path); err == nil && !atomic {
+		needed -= info.Size()
+	}
+
+	if needed > available {
+		return fmt.Errorf("insufficient disk space: writing %s needs %d bytes but only %d are available", path, needed, available)
+	}
+	return nil
+}
@@ -3,22 +3,65 @@ package main
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
 
 	"github.com/eadydb/zephyr/pkg/plugin"
 )
 
+const (
+	// readChunkSize is how much is read between context cancellation checks
+	readChunkSize = 64 * 1024
+
+	// defaultMaxDepth limits how deep a recursive listing descends by default
+	defaultMaxDepth = 10
+
+	// defaultFileMode and defaultDirMode apply to writes without file_mode or dir_mode
+	defaultFileMode os.FileMode = 0o644
+	defaultDirMode  os.FileMode = 0o755
+
+	// fileURIPrefix starts the URI of every file exposed as a resource
+	fileURIPrefix = "file://"
+)
+
 // Plugin is the exported plugin instance
-var Plugin plugin.DynamicPlugin = &FileOpsPlugin{}
+var Plugin plugin.DynamicPlugin = NewPlugin()
 
 // FileOpsPlugin implements the DynamicPlugin interface
 type FileOpsPlugin struct {
-	initialized bool
-	maxFileSize int64 // Maximum file size to read (in bytes)
+	initialized atomic.Bool // read by Execute while a reload runs Initialize or Shutdown
+	maxFileSize int64       // Maximum file size to read (in bytes)
+}
+
+// fileOpsArgs are the bound arguments of a fileops call
+type fileOpsArgs struct {
+	Operation        string  `json:"operation" validate:"required,oneof=read write list stat exists mkdir chmod touch"`
+	Path             string  `json:"path" validate:"required"`
+	Content          *string `json:"content"` // nil when absent; empty content is a valid write
+	Encoding         string  `json:"encoding"`
+	FallbackEncoding string  `json:"fallback_encoding" validate:"oneof=base64 latin1"`
+	ExpectedType     string  `json:"expected_type"`
+	FileMode         string  `json:"file_mode"`
+	DirMode          string  `json:"dir_mode"`
+	CreateDirs       bool    `json:"create_dirs"`
+	Atomic           bool    `json:"atomic"`
+	Recursive        bool    `json:"recursive"`
+	Parents          bool    `json:"parents"` // alias for recursive on mkdir
+	Mode             string  `json:"mode"`
+	FollowSymlinks   bool    `json:"follow_symlinks"`
+	Timestamp        string  `json:"timestamp"`
+	MaxDepth         int     `json:"max_depth" validate:"min=0"`
+	DryRun           bool    `json:"-"`
 }
 
 // NewPlugin is the factory function that will be called by the plugin loader
@@ -45,16 +88,15 @@ func (p *FileOpsPlugin) Description() string {
 
 // Initialize initializes the plugin
 func (p *FileOpsPlugin) Initialize() error {
-	if p.initialized {
+	if !p.initialized.CompareAndSwap(false, true) {
 		return fmt.Errorf("plugin already initialized")
 	}
-	p.initialized = true
 	return nil
 }
 
 // Shutdown cleans up the plugin
 func (p *FileOpsPlugin) Shutdown() error {
-	p.initialized = false
+	p.initialized.Store(false)
 	return nil
 }
 
@@ -62,14 +104,14 @@ func (p *FileOpsPlugin) Shutdown() error {
 func (p *FileOpsPlugin) MCPToolDefinition() plugin.MCPTool {
 	return plugin.MCPTool{
 		Name:        "fileops",
-		Description: "File system operations: read, write, list, stat, exists",
+		Description: "File system operations: read, write, list, stat, exists, mkdir, chmod, touch",
 		InputSchema: map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
 				"operation": map[string]interface{}{
 					"type":        "string",
-					"description": "File operation: 'read', 'write', 'list', 'stat', 'exists'",
-					"enum":        []string{"read", "write", "list", "stat", "exists"},
+					"description": "File operation: 'read', 'write', 'list', 'stat', 'exists', 'mkdir', 'chmod', 'touch'",
+					"enum":        []string{"read", "write", "list", "stat", "exists", "mkdir", "chmod", "touch"},
 				},
 				"path": map[string]interface{}{
 					"type":        "string",
@@ -81,12 +123,67 @@ func (p *FileOpsPlugin) MCPToolDefinition() plugin.MCPTool {
 				},
 				"encoding": map[string]interface{}{
 					"type":        "string",
-					"description": "Encoding for content: 'utf8' or 'base64'",
+					"description": "Encoding for content: 'utf8', 'base64', or 'auto' (read only: utf8 if valid, otherwise the fallback encoding)",
 					"default":     "utf8",
 				},
+				"fallback_encoding": map[string]interface{}{
+					"type":        "string",
+					"description": "Encoding used by 'auto' when content is not valid UTF-8: 'base64' or 'latin1'",
+					"default":     "base64",
+				},
+				"expected_type": map[string]interface{}{
+					"type":        "string",
+					"description": "MIME type the written content must match (for write operation). Text types reject content that is not valid UTF-8",
+				},
+				"file_mode": map[string]interface{}{
+					"type":        "string",
+					"description": "Octal permissions for the written or created file, e.g. '0600' (for write and touch operations). New files get 0644 and existing files keep their permissions when omitted",
+				},
+				"dir_mode": map[string]interface{}{
+					"type":        "string",
+					"description": "Octal permissions for directories created by create_dirs, e.g. '0700'. Defaults to 0755",
+				},
+				"atomic": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Write to a temporary file and rename it into place so a crash never leaves a partial file (for write operation)",
+					"default":     false,
+				},
 				"create_dirs": map[string]interface{}{
 					"type":        "boolean",
-					"description": "Create parent directories if they don't exist (for write operation)",
+					"description": "Create parent directories if they don't exist (for write and touch operations)",
+					"default":     false,
+				},
+				"recursive": map[string]interface{}{
+					"type":        "boolean",
+					"description": "List subdirectories recursively (for list operation), or create missing parents (for mkdir operation)",
+					"default":     false,
+				},
+				"parents": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Create missing parent directories (for mkdir operation, same as recursive)",
+					"default":     false,
+				},
+				"mode": map[string]interface{}{
+					"type":        "string",
+					"description": "Octal permissions for the created directory (for mkdir operation, default '0755') or the new permissions, e.g. '0755' (required for chmod operation)",
+				},
+				"timestamp": map[string]interface{}{
+					"type":        "string",
+					"description": "RFC 3339 time to set as the access and modification time instead of now (for touch operation)",
+				},
+				"follow_symlinks": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Allow chmod on a symlink, changing the file it points to (for chmod operation)",
+					"default":     false,
+				},
+				"max_depth": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum directory depth for recursive listing",
+					"default":     defaultMaxDepth,
+				},
+				plugin.DryRunArg: map[string]interface{}{
+					"type":        "boolean",
+					"description": "Validate a write and report what it would do without touching the file system",
 					"default":     false,
 				},
 			},
@@ -95,6 +192,85 @@ func (p *FileOpsPlugin) MCPToolDefinition() plugin.MCPTool {
 	}
 }
 
+// Capabilities advertises the supported operations, encodings, and limits
+func (p *FileOpsPlugin) Capabilities() map[string]interface{} {
+	return map[string]interface{}{
+		"operations":         []string{"read", "write", "list", "stat", "exists", "mkdir", "chmod", "touch"},
+		"read_encodings":     []string{"utf8", "base64", "auto"},
+		"write_encodings":    []string{"utf8", "base64"},
+		"fallback_encodings": []string{"base64", "latin1"},
+		"max_file_size":      p.maxFileSize,
+		"recursive_list":     true,
+		"atomic_write":       true,
+		"default_max_depth":  defaultMaxDepth,
+	}
+}
+
+// SupportsDryRun reports that write, mkdir, chmod, and touch calls can be pre-flighted; read-only operations run normally
+func (p *FileOpsPlugin) SupportsDryRun() bool {
+	return true
+}
+
+// Resources exposes files as MCP resources addressed by file URIs
+func (p *FileOpsPlugin) Resources() []plugin.Resource {
+	return []plugin.Resource{{
+		URITemplate: fileURIPrefix + "{+path}",
+		Name:        "File",
+		Description: "Contents of a file by absolute path, such as file:///etc/hosts; subject to the same limits as the read operation",
+	}}
+}
+
+// ReadResource reads a file named by a file URI. Text files are returned as
+// text and anything else as a blob.
+func (p *FileOpsPlugin) ReadResource(ctx context.Context, uri string) ([]plugin.ResourceContent, error) {
+	if !p.initialized.Load() {
+		return nil, plugin.ErrNotInitialized
+	}
+
+	escaped, found := strings.CutPrefix(uri, fileURIPrefix)
+	if !found || !strings.HasPrefix(escaped, "/") {
+		return nil, fmt.Errorf("%w: not an absolute file URI: %s", plugin.ErrInvalidArguments, uri)
+	}
+	path, err := url.PathUnescape(escaped)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed file URI %s: %v", plugin.ErrInvalidArguments, uri, err)
+	}
+	cleanPath, err := p.validatePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+
+	info, err := os.Stat(cleanPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("path is a directory, not a file: %s", cleanPath)
+	}
+	if info.Size() > p.maxFileSize {
+		return nil, fmt.Errorf("file too large: %d bytes (max: %d bytes)", info.Size(), p.maxFileSize)
+	}
+
+	content, err := p.readWithContext(ctx, cleanPath, info.Size())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	mimeType := mime.TypeByExtension(filepath.Ext(cleanPath))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(content)
+	}
+	mediaType, _, _ := mime.ParseMediaType(mimeType)
+
+	resource := plugin.ResourceContent{URI: uri, MIMEType: mimeType}
+	if isTextMIMEType(mediaType) && utf8.Valid(content) {
+		resource.Text = string(content)
+	} else {
+		resource.Blob = content
+	}
+	return []plugin.ResourceContent{resource}, nil
+}
+
 // InputSchema returns the input schema for the tool
 func (p *FileOpsPlugin) InputSchema() map[string]interface{} {
 	return p.MCPToolDefinition().InputSchema
@@ -102,43 +278,54 @@ func (p *FileOpsPlugin) InputSchema() map[string]interface{} {
 
 // Execute executes the tool with the given arguments
 func (p *FileOpsPlugin) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
-	if !p.initialized {
-		return nil, fmt.Errorf("plugin not initialized")
+	if !p.initialized.Load() {
+		return nil, plugin.ErrNotInitialized
 	}
 
-	// Parse operation
-	operation, ok := args["operation"].(string)
-	if !ok {
-		return nil, fmt.Errorf("operation parameter is required and must be a string")
+	// Bind arguments over their defaults
+	opts := fileOpsArgs{
+		Encoding:         "utf8",
+		FallbackEncoding: "base64",
+		MaxDepth:         defaultMaxDepth,
+		DryRun:           plugin.IsDryRun(args),
 	}
-
-	// Parse path
-	path, ok := args["path"].(string)
-	if !ok {
-		return nil, fmt.Errorf("path parameter is required and must be a string")
+	if err := plugin.BindArgs(args, &opts); err != nil {
+		return nil, err
 	}
 
 	// Validate and clean path
-	cleanPath, err := p.validatePath(path)
+	cleanPath, err := p.validatePath(opts.Path)
 	if err != nil {
 		return nil, fmt.Errorf("invalid path: %w", err)
 	}
 
 	// Execute operation
-	switch operation {
+	var result interface{}
+	switch opts.Operation {
 	case "read":
-		return p.readFile(cleanPath, args)
+		result, err = p.readFile(ctx, cleanPath, &opts)
 	case "write":
-		return p.writeFile(cleanPath, args)
+		result, err = p.writeFile(cleanPath, &opts)
 	case "list":
-		return p.listDirectory(cleanPath)
+		result, err = p.listDirectory(ctx, cleanPath, &opts)
 	case "stat":
-		return p.statFile(cleanPath)
+		result, err = p.statFile(cleanPath)
 	case "exists":
-		return p.fileExists(cleanPath)
+		result, err = p.fileExists(cleanPath)
+	case "mkdir":
+		result, err = p.makeDirectory(cleanPath, &opts)
+	case "chmod":
+		result, err = p.changeMode(cleanPath, &opts)
+	case "touch":
+		result, err = p.touchFile(cleanPath, &opts)
 	default:
-		return nil, fmt.Errorf("unsupported operation: %s", operation)
+		return nil, fmt.Errorf("%w: unsupported operation: %s", plugin.ErrInvalidArguments, opts.Operation)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
 // validatePath validates and cleans the file path
@@ -161,7 +348,7 @@ func (p *FileOpsPlugin) validatePath(path string) (string, error) {
 }
 
 // readFile reads a file and returns its content
-func (p *FileOpsPlugin) readFile(path string, args map[string]interface{}) (interface{}, error) {
+func (p *FileOpsPlugin) readFile(ctx context.Context, path string, opts *fileOpsArgs) (interface{}, error) {
 	// Check if file exists
 	info, err := os.Stat(path)
 	if err != nil {
@@ -182,18 +369,12 @@ func (p *FileOpsPlugin) readFile(path string, args map[string]interface{}) (inte
 	}
 
 	// Read file
-	content, err := os.ReadFile(path)
+	content, err := p.readWithContext(ctx, path, info.Size())
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	// Parse encoding
-	encoding := "utf8"
-	if enc, exists := args["encoding"]; exists {
-		if e, ok := enc.(string); ok {
-			encoding = e
-		}
-	}
+	encoding := opts.Encoding
 
 	// Prepare result
 	result := map[string]interface{}{
@@ -203,12 +384,24 @@ func (p *FileOpsPlugin) readFile(path string, args map[string]interface{}) (inte
 		"encoding":  encoding,
 	}
 
+	// Resolve automatic detection to a concrete encoding
+	if encoding == "auto" {
+		detected, err := p.detectEncoding(content, opts.FallbackEncoding)
+		if err != nil {
+			return nil, err
+		}
+		encoding = detected
+		result["encoding_detected"] = detected
+	}
+
 	// Encode content based on requested encoding
 	switch encoding {
 	case "utf8":
 		result["content"] = string(content)
 	case "base64":
 		result["content"] = base64.StdEncoding.EncodeToString(content)
+	case "latin1":
+		result["content"] = decodeLatin1(content)
 	default:
 		return nil, fmt.Errorf("unsupported encoding: %s", encoding)
 	}
@@ -216,38 +409,78 @@ func (p *FileOpsPlugin) readFile(path string, args map[string]interface{}) (inte
 	return p.jsonResponse(result)
 }
 
-// writeFile writes content to a file
-func (p *FileOpsPlugin) writeFile(path string, args map[string]interface{}) (interface{}, error) {
-	// Parse content
-	content, ok := args["content"].(string)
-	if !ok {
-		return nil, fmt.Errorf("content parameter is required for write operation")
+// detectEncoding picks utf8 for valid UTF-8 content and the requested fallback otherwise
+func (p *FileOpsPlugin) detectEncoding(content []byte, fallback string) (string, error) {
+	if utf8.Valid(content) {
+		return "utf8", nil
 	}
 
-	// Parse encoding
-	encoding := "utf8"
-	if enc, exists := args["encoding"]; exists {
-		if e, ok := enc.(string); ok {
-			encoding = e
-		}
+	switch fallback {
+	case "base64", "latin1":
+		return fallback, nil
+	default:
+		return "", fmt.Errorf("unsupported fallback encoding: %s", fallback)
+	}
+}
+
+// decodeLatin1 converts ISO-8859-1 bytes to a UTF-8 string
+func decodeLatin1(content []byte) string {
+	runes := make([]rune, len(content))
+	for i, b := range content {
+		runes[i] = rune(b)
 	}
+	return string(runes)
+}
 
-	// Parse create_dirs flag
-	createDirs := false
-	if cd, exists := args["create_dirs"]; exists {
-		if c, ok := cd.(bool); ok {
-			createDirs = c
+// readWithContext reads a file in chunks, stopping as soon as the context is cancelled
+func (p *FileOpsPlugin) readWithContext(ctx context.Context, path string, sizeHint int64) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	content := make([]byte, 0, sizeHint)
+	chunk := make([]byte, readChunkSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		n, err := file.Read(chunk)
+		content = append(content, chunk[:n]...)
+
+		// The file may have grown since it was stat'ed
+		if int64(len(content)) > p.maxFileSize {
+			return nil, fmt.Errorf("file too large: exceeds %d bytes", p.maxFileSize)
+		}
+
+		if err == io.EOF {
+			return content, nil
+		}
+		if err != nil {
+			return nil, err
 		}
 	}
+}
+
+// writeFile writes content to a file
+func (p *FileOpsPlugin) writeFile(path string, opts *fileOpsArgs) (interface{}, error) {
+	if opts.Content == nil {
+		return nil, fmt.Errorf("%w: content is required for write operation", plugin.ErrInvalidArguments)
+	}
+	encoding := opts.Encoding
+	createDirs := opts.CreateDirs
+	atomic := opts.Atomic
 
 	// Decode content based on encoding
 	var data []byte
 	var err error
 	switch encoding {
 	case "utf8":
-		data = []byte(content)
+		data = []byte(*opts.Content)
 	case "base64":
-		data, err = base64.StdEncoding.DecodeString(content)
+		data, err = base64.StdEncoding.DecodeString(*opts.Content)
 		if err != nil {
 			return nil, fmt.Errorf("invalid base64 content: %w", err)
 		}
@@ -255,32 +488,401 @@ func (p *FileOpsPlugin) writeFile(path string, args map[string]interface{}) (int
 		return nil, fmt.Errorf("unsupported encoding: %s", encoding)
 	}
 
+	// Parse permissions
+	fileMode, fileModeSet, err := parseMode("file_mode", opts.FileMode, defaultFileMode)
+	if err != nil {
+		return nil, err
+	}
+	dirMode, _, err := parseMode("dir_mode", opts.DirMode, defaultDirMode)
+	if err != nil {
+		return nil, err
+	}
+
+	contentTypes, err := p.checkContentType(path, data, opts.ExpectedType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{
+		"operation":   "write",
+		"path":        path,
+		"size":        len(data),
+		"encoding":    encoding,
+		"create_dirs": createDirs,
+		"atomic":      atomic,
+	}
+	if fileModeSet {
+		result["file_mode"] = fmt.Sprintf("%04o", fileMode)
+	}
+	for key, value := range contentTypes {
+		result[key] = value
+	}
+
+	if err := checkFreeSpace(path, int64(len(data)), atomic); err != nil {
+		return nil, err
+	}
+
+	if opts.DryRun {
+		return p.planWrite(path, result, createDirs)
+	}
+
 	// Create parent directories if requested
 	if createDirs {
 		dir := filepath.Dir(path)
-		if err := os.MkdirAll(dir, 0o755); err != nil {
+		if err := os.MkdirAll(dir, dirMode); err != nil {
 			return nil, fmt.Errorf("failed to create directories: %w", err)
 		}
 	}
 
 	// Write file
-	if err := os.WriteFile(path, data, 0o644); err != nil {
+	if atomic {
+		err = writeFileAtomic(path, data, fileMode, fileModeSet)
+	} else {
+		err = os.WriteFile(path, data, fileMode)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to write file: %w", err)
 	}
 
+	// WriteFile only applies the mode to new files, so set an explicit one on existing files too
+	if fileModeSet && !atomic {
+		if err := os.Chmod(path, fileMode); err != nil {
+			return nil, fmt.Errorf("failed to set file mode: %w", err)
+		}
+	}
+
+	return p.jsonResponse(result)
+}
+
+// parseMode parses the octal permission string such as "0600" given for the
+// argument key, reporting whether it was set and returning def when it is empty
+func parseMode(key, str string, def os.FileMode) (os.FileMode, bool, error) {
+	if str == "" {
+		return def, false, nil
+	}
+
+	mode, err := strconv.ParseUint(str, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, false, fmt.Errorf("%w: invalid %s %q: must be an octal permission between 0000 and 0777", plugin.ErrInvalidArguments, key, str)
+	}
+
+	return os.FileMode(mode), true, nil
+}
+
+// writeFileAtomic writes data to a temporary file in the target's directory and
+// renames it into place, so readers and crashes only ever see the old or the new
+// content. An existing target keeps its permissions unless override is set.
+func writeFileAtomic(path string, data []byte, perm os.FileMode, override bool) (err error) {
+	// Rename onto the symlink target rather than the link, so the link survives
+	// just as it would a plain write
+	if resolved, resolveErr := filepath.EvalSymlinks(path); resolveErr == nil {
+		path = resolved
+	}
+
+	if info, statErr := os.Stat(path); statErr == nil && !override {
+		perm = info.Mode().Perm()
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+
+	// Persist the rename; not every platform can sync a directory
+	if d, dirErr := os.Open(dir); dirErr == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+// textMIMETypes are non-text/* MIME types whose content is text
+var textMIMETypes = map[string]bool{
+	"application/json":       true,
+	"application/xml":        true,
+	"application/javascript": true,
+	"application/yaml":       true,
+	"application/x-yaml":     true,
+	"application/toml":       true,
+	"application/x-sh":       true,
+}
+
+// checkContentType resolves the declared MIME type of a write, from expected_type
+// or else the file extension, and sniffs the detected type of the data. Content
+// explicitly declared as text must be valid UTF-8 without NUL bytes.
+func (p *FileOpsPlugin) checkContentType(path string, data []byte, expected string) (map[string]interface{}, error) {
+	types := map[string]interface{}{
+		"detected_type": http.DetectContentType(data),
+	}
+
+	declared := expected
+	if declared == "" {
+		declared = mime.TypeByExtension(filepath.Ext(path))
+	}
+	if declared == "" {
+		return types, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid expected_type %q: %v", plugin.ErrInvalidArguments, declared, err)
+	}
+	types["content_type"] = mediaType
+
+	// Only an explicit declaration is enforced so existing writes keep working
+	if expected != "" && isTextMIMEType(mediaType) {
+		if !utf8.Valid(data) || strings.ContainsRune(string(data), 0) {
+			return nil, fmt.Errorf("%w: content is binary but expected_type is %s", plugin.ErrInvalidArguments, mediaType)
+		}
+	}
+
+	return types, nil
+}
+
+// isTextMIMEType reports whether a media type holds text
+func isTextMIMEType(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "+json") ||
+		strings.HasSuffix(mediaType, "+xml") ||
+		textMIMETypes[mediaType]
+}
+
+// planWrite reports what a write would do without performing it
+func (p *FileOpsPlugin) planWrite(path string, result map[string]interface{}, createDirs bool) (interface{}, error) {
+	result["dry_run"] = true
+
+	// Report whether an existing file would be overwritten
+	if info, err := os.Stat(path); err == nil {
+		if info.IsDir() {
+			return nil, fmt.Errorf("path is a directory, not a file: %s", path)
+		}
+		result["would_overwrite"] = true
+		result["current_size"] = info.Size()
+	} else if os.IsNotExist(err) {
+		result["would_overwrite"] = false
+	} else {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	// Report whether the parent directory exists or would be created
+	dir := filepath.Dir(path)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if !createDirs {
+			return nil, fmt.Errorf("parent directory does not exist: %s", dir)
+		}
+		result["would_create_dirs"] = true
+	}
+
+	return p.jsonResponse(result)
+}
+
+// makeDirectory creates a directory, and its parents when recursive or parents
+// is set. An existing directory is not an error; the result reports it.
+func (p *FileOpsPlugin) makeDirectory(path string, opts *fileOpsArgs) (interface{}, error) {
+	mode, modeSet, err := parseMode("mode", opts.Mode, defaultDirMode)
+	if err != nil {
+		return nil, err
+	}
+	parents := opts.Recursive || opts.Parents
+
+	existed := false
+	var existingMode os.FileMode
+	if info, err := os.Stat(path); err == nil {
+		if !info.IsDir() {
+			return nil, fmt.Errorf("path exists and is not a directory: %s", path)
+		}
+		existed = true
+		existingMode = info.Mode().Perm()
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to stat directory: %w", err)
+	}
+
 	result := map[string]interface{}{
-		"operation":   "write",
-		"path":        path,
-		"size":        len(data),
-		"encoding":    encoding,
-		"create_dirs": createDirs,
+		"operation": "mkdir",
+		"path":      path,
+		"parents":   parents,
+		"existed":   existed,
+	}
+	// An existing directory is left as it is, so report the mode it actually has
+	if existed {
+		result["mode"] = fmt.Sprintf("%04o", existingMode)
+	} else if modeSet {
+		result["mode"] = fmt.Sprintf("%04o", mode)
+	}
+
+	if opts.DryRun {
+		result["dry_run"] = true
+		if !existed && !parents {
+			if _, err := os.Stat(filepath.Dir(path)); os.IsNotExist(err) {
+				return nil, fmt.Errorf("parent directory does not exist: %s", filepath.Dir(path))
+			}
+		}
+		return p.jsonResponse(result)
+	}
+
+	if existed {
+		return p.jsonResponse(result)
+	}
+
+	if parents {
+		err = os.MkdirAll(path, mode)
+	} else {
+		err = os.Mkdir(path, mode)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Mkdir applies the umask, so set an explicit mode exactly
+	if modeSet {
+		if err := os.Chmod(path, mode); err != nil {
+			return nil, fmt.Errorf("failed to set directory mode: %w", err)
+		}
+	}
+
+	return p.jsonResponse(result)
+}
+
+// changeMode sets the permissions of a path. Symlinks are refused unless
+// follow_symlinks is set, since chmod changes the target rather than the link.
+func (p *FileOpsPlugin) changeMode(path string, opts *fileOpsArgs) (interface{}, error) {
+	if opts.Mode == "" {
+		return nil, fmt.Errorf("%w: mode is required for chmod operation", plugin.ErrInvalidArguments)
+	}
+	mode, _, err := parseMode("mode", opts.Mode, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("file not found: %s", path)
+		}
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		if !opts.FollowSymlinks {
+			return nil, fmt.Errorf("%w: path is a symlink, set follow_symlinks to change its target: %s", plugin.ErrInvalidArguments, path)
+		}
+		if info, err = os.Stat(path); err != nil {
+			return nil, fmt.Errorf("failed to stat symlink target: %w", err)
+		}
+	}
+
+	result := map[string]interface{}{
+		"operation": "chmod",
+		"path":      path,
+		"old_mode":  fmt.Sprintf("%04o", info.Mode().Perm()),
+		"new_mode":  fmt.Sprintf("%04o", mode),
+	}
+
+	if opts.DryRun {
+		result["dry_run"] = true
+		return p.jsonResponse(result)
+	}
+
+	if err := os.Chmod(path, mode); err != nil {
+		return nil, fmt.Errorf("failed to change mode: %w", err)
+	}
+
+	return p.jsonResponse(result)
+}
+
+// touchFile creates an empty file if absent and sets its access and
+// modification times to now or the given timestamp
+func (p *FileOpsPlugin) touchFile(path string, opts *fileOpsArgs) (interface{}, error) {
+	when := time.Now()
+	if opts.Timestamp != "" {
+		parsed, err := time.Parse(time.RFC3339, opts.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid timestamp %q: must be RFC 3339", plugin.ErrInvalidArguments, opts.Timestamp)
+		}
+		when = parsed
+	}
+
+	fileMode, _, err := parseMode("file_mode", opts.FileMode, defaultFileMode)
+	if err != nil {
+		return nil, err
+	}
+	dirMode, _, err := parseMode("dir_mode", opts.DirMode, defaultDirMode)
+	if err != nil {
+		return nil, err
+	}
+
+	created := false
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		created = true
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	result := map[string]interface{}{
+		"operation": "touch",
+		"path":      path,
+		"created":   created,
+		"modtime":   when.Format(time.RFC3339),
+	}
+
+	dir := filepath.Dir(path)
+	if opts.DryRun {
+		result["dry_run"] = true
+		if _, err := os.Stat(dir); created && os.IsNotExist(err) {
+			if !opts.CreateDirs {
+				return nil, fmt.Errorf("parent directory does not exist: %s", dir)
+			}
+			result["would_create_dirs"] = true
+		}
+		return p.jsonResponse(result)
+	}
+
+	if created {
+		if opts.CreateDirs {
+			if err := os.MkdirAll(dir, dirMode); err != nil {
+				return nil, fmt.Errorf("failed to create directories: %w", err)
+			}
+		}
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, fileMode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create file: %w", err)
+		}
+		file.Close()
+	}
+
+	if err := os.Chtimes(path, when, when); err != nil {
+		return nil, fmt.Errorf("failed to set file times: %w", err)
 	}
 
 	return p.jsonResponse(result)
 }
 
 // listDirectory lists directory contents
-func (p *FileOpsPlugin) listDirectory(path string) (interface{}, error) {
+func (p *FileOpsPlugin) listDirectory(ctx context.Context, path string, opts *fileOpsArgs) (interface{}, error) {
 	// Check if directory exists
 	info, err := os.Stat(path)
 	if err != nil {
@@ -295,6 +897,10 @@ func (p *FileOpsPlugin) listDirectory(path string) (interface{}, error) {
 		return nil, fmt.Errorf("path is not a directory: %s", path)
 	}
 
+	if opts.Recursive {
+		return p.listRecursive(ctx, path, opts.MaxDepth)
+	}
+
 	// Read directory
 	entries, err := os.ReadDir(path)
 	if err != nil {
@@ -328,6 +934,122 @@ func (p *FileOpsPlugin) listDirectory(path string) (interface{}, error) {
 	return p.jsonResponse(result)
 }
 
+// walkState tracks progress of a recursive directory walk
+type walkState struct {
+	ctx      context.Context
+	maxDepth int
+	visited  map[string]bool // directory identities already walked
+	files    []map[string]interface{}
+	skipped  []map[string]interface{}
+}
+
+// listRecursive lists a directory tree, following symlinked directories but
+// never entering the same directory twice so symlink cycles cannot recurse forever
+func (p *FileOpsPlugin) listRecursive(ctx context.Context, path string, maxDepth int) (interface{}, error) {
+	rootID, err := p.directoryID(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat directory: %w", err)
+	}
+
+	state := &walkState{
+		ctx:      ctx,
+		maxDepth: maxDepth,
+		visited:  map[string]bool{rootID: true},
+	}
+
+	if err := p.walkDirectory(state, path, "", 0); err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	result := map[string]interface{}{
+		"operation": "list",
+		"path":      path,
+		"recursive": true,
+		"max_depth": maxDepth,
+		"count":     len(state.files),
+		"files":     state.files,
+		"skipped":   state.skipped,
+	}
+
+	return p.jsonResponse(result)
+}
+
+// walkDirectory appends the entries of dir to the walk state and descends into subdirectories
+func (p *FileOpsPlugin) walkDirectory(state *walkState, dir, relDir string, depth int) error {
+	if err := state.ctx.Err(); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		fileInfo, err := entry.Info()
+		if err != nil {
+			continue // Skip entries with errors
+		}
+
+		entryPath := filepath.Join(dir, entry.Name())
+		relPath := filepath.Join(relDir, entry.Name())
+
+		state.files = append(state.files, map[string]interface{}{
+			"name":    entry.Name(),
+			"path":    relPath,
+			"type":    p.getFileType(entry),
+			"size":    fileInfo.Size(),
+			"mode":    fileInfo.Mode().String(),
+			"modtime": fileInfo.ModTime().Format("2006-01-02 15:04:05"),
+		})
+
+		// Follow real directories and symlinks that resolve to directories
+		target, err := os.Stat(entryPath)
+		if err != nil || !target.IsDir() {
+			continue
+		}
+
+		if depth >= state.maxDepth {
+			state.skipped = append(state.skipped, map[string]interface{}{"path": relPath, "reason": "max_depth"})
+			continue
+		}
+
+		id, err := p.directoryID(entryPath)
+		if err != nil {
+			state.skipped = append(state.skipped, map[string]interface{}{"path": relPath, "reason": err.Error()})
+			continue
+		}
+		if state.visited[id] {
+			state.skipped = append(state.skipped, map[string]interface{}{"path": relPath, "reason": "cycle"})
+			continue
+		}
+		state.visited[id] = true
+
+		if err := p.walkDirectory(state, entryPath, relPath, depth+1); err != nil {
+			if state.ctx.Err() != nil {
+				return err
+			}
+			state.skipped = append(state.skipped, map[string]interface{}{"path": relPath, "reason": err.Error()})
+		}
+	}
+
+	return nil
+}
+
+// resolvedDirectoryID identifies a directory by its absolute path with every
+// symlink resolved, for platforms without device and inode numbers
+func resolvedDirectoryID(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Clean(resolved), nil
+}
+
 // statFile gets file/directory metadata
 func (p *FileOpsPlugin) statFile(path string) (interface{}, error) {
 	info, err := os.Stat(path)
@@ -377,13 +1099,9 @@ func (p *FileOpsPlugin) getFileType(entry os.DirEntry) string {
 	return "file"
 }
 
-// jsonResponse converts result to JSON string
+// jsonResponse wraps result in a typed result the server encodes as JSON
 func (p *FileOpsPlugin) jsonResponse(result map[string]interface{}) (interface{}, error) {
-	jsonBytes, err := json.Marshal(result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
-	}
-	return string(jsonBytes), nil
+	return plugin.JSONResult(result), nil
 }
 
 // main function is required for plugin compilation but won't be used
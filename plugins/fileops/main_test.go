@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/eadydb/zephyr/pkg/plugin"
+)
+
+// TestConcurrentExecuteAndShutdown runs calls while a reload repeatedly shuts
+// the plugin down and initializes it again; run with -race
+func TestConcurrentExecuteAndShutdown(t *testing.T) {
+	p := NewPlugin()
+	if err := p.Initialize(); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, err := p.Execute(context.Background(), map[string]interface{}{
+					"operation": "exists",
+					"path":      "main.go",
+				})
+				if err != nil && !errors.Is(err, plugin.ErrNotInitialized) {
+					t.Errorf("Execute: %v", err)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < 100; j++ {
+			if err := p.Shutdown(); err != nil {
+				t.Errorf("Shutdown: %v", err)
+				return
+			}
+			if err := p.Initialize(); err != nil {
+				t.Errorf("Initialize: %v", err)
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+}
+
+// TestWriteFileAtomicKeepsSymlink checks that an atomic write through a symlink
+// replaces the target's content and leaves the link in place
+func TestWriteFileAtomicKeepsSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.txt")
+	if err := os.WriteFile(target, []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link.txt")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	if err := writeFileAtomic(link, []byte("new"), defaultFileMode, false); err != nil {
+		t.Fatalf("writeFileAtomic: %v", err)
+	}
+
+	info, err := os.Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("link was replaced by a %v", info.Mode().Type())
+	}
+	data, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "new" {
+		t.Errorf("target content = %q, want %q", data, "new")
+	}
+	if info, err := os.Stat(target); err == nil && info.Mode().Perm() != 0o600 {
+		t.Errorf("target mode = %04o, want 0600", info.Mode().Perm())
+	}
+}
+
+// TestMakeDirectoryReportsExistingMode checks that mkdir on an existing
+// directory reports its actual mode rather than the requested one
+func TestMakeDirectoryReportsExistingMode(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "existing")
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &FileOpsPlugin{}
+	result, err := p.makeDirectory(dir, &fileOpsArgs{Mode: "0755"})
+	if err != nil {
+		t.Fatalf("makeDirectory: %v", err)
+	}
+
+	data := result.(*plugin.ToolResult).Content[0].Data.(map[string]interface{})
+	if data["mode"] != "0700" {
+		t.Errorf("mode = %v, want 0700", data["mode"])
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// collectDisk is unsupported without statfs; the error is reported under the
+// disk section so the rest of the report is still returned
+func collectDisk(path string) (interface{}, error) {
+	return nil, fmt.Errorf("disk usage is not supported on %s", runtime.GOOS)
+}
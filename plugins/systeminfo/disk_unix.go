@@ -0,0 +1,24 @@
+//go:build unix
+
+package main
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// collectDisk reports capacity and free space of the filesystem containing path
+func collectDisk(path string) (interface{}, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return nil, fmt.Errorf("failed to stat filesystem %s: %w", path, err)
+	}
+
+	blockSize := uint64(stat.Bsize)
+	return map[string]interface{}{
+		"path":        path,
+		"total_bytes": stat.Blocks * blockSize,
+		"free_bytes":  stat.Bfree * blockSize,
+		"avail_bytes": stat.Bavail * blockSize,
+	}, nil
+}
@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"net"
 	"runtime"
+	"sync/atomic"
+	"time"
 
 	"github.com/eadydb/zephyr/pkg/plugin"
 )
@@ -14,9 +16,12 @@ var Plugin plugin.DynamicPlugin = &SystemInfoPlugin{}
 
 // SystemInfoPlugin implements the DynamicPlugin interface
 type SystemInfoPlugin struct {
-	initialized bool
+	initialized atomic.Bool // read by Execute while a reload runs Initialize or Shutdown
 }
 
+// sectionTimeout bounds collectors that make syscalls which could block
+const sectionTimeout = 2 * time.Second
+
 // NewPlugin is the factory function that will be called by the plugin loader
 func NewPlugin() plugin.DynamicPlugin {
 	return &SystemInfoPlugin{}
@@ -39,16 +44,15 @@ func (p *SystemInfoPlugin) Description() string {
 
 // Initialize initializes the plugin
 func (p *SystemInfoPlugin) Initialize() error {
-	if p.initialized {
+	if !p.initialized.CompareAndSwap(false, true) {
 		return fmt.Errorf("plugin already initialized")
 	}
-	p.initialized = true
 	return nil
 }
 
 // Shutdown cleans up the plugin
 func (p *SystemInfoPlugin) Shutdown() error {
-	p.initialized = false
+	p.initialized.Store(false)
 	return nil
 }
 
@@ -65,6 +69,19 @@ func (p *SystemInfoPlugin) MCPToolDefinition() plugin.MCPTool {
 					"description": "Whether to include detailed memory statistics",
 					"default":     true,
 				},
+				"sections": map[string]interface{}{
+					"type":        "array",
+					"description": "Optional sections to include ('memory', 'disk', 'network'). Defaults to 'memory' when detailed is true",
+					"items": map[string]interface{}{
+						"type": "string",
+						"enum": []string{"memory", "disk", "network"},
+					},
+				},
+				"path": map[string]interface{}{
+					"type":        "string",
+					"description": "Filesystem path to report disk usage for",
+					"default":     "/",
+				},
 			},
 		},
 	}
@@ -77,16 +94,14 @@ func (p *SystemInfoPlugin) InputSchema() map[string]interface{} {
 
 // Execute executes the tool with the given arguments
 func (p *SystemInfoPlugin) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
-	if !p.initialized {
-		return nil, fmt.Errorf("plugin not initialized")
+	if !p.initialized.Load() {
+		return nil, plugin.ErrNotInitialized
 	}
 
 	// Parse detailed flag
-	detailed := true
-	if detailedArg, exists := args["detailed"]; exists {
-		if d, ok := detailedArg.(bool); ok {
-			detailed = d
-		}
+	detailed, err := plugin.BoolArg(args, "detailed", true)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get basic system info
@@ -98,32 +113,138 @@ func (p *SystemInfoPlugin) Execute(ctx context.Context, args map[string]interfac
 		"goroutines": runtime.NumGoroutine(),
 	}
 
-	// Add detailed memory info if requested
+	sections := []string{}
 	if detailed {
-		var memStats runtime.MemStats
-		runtime.ReadMemStats(&memStats)
-
-		info["memory"] = map[string]interface{}{
-			"alloc":        memStats.Alloc,
-			"total_alloc":  memStats.TotalAlloc,
-			"sys":          memStats.Sys,
-			"heap_alloc":   memStats.HeapAlloc,
-			"heap_sys":     memStats.HeapSys,
-			"heap_idle":    memStats.HeapIdle,
-			"heap_inuse":   memStats.HeapInuse,
-			"heap_objects": memStats.HeapObjects,
-			"gc_cycles":    memStats.NumGC,
-			"gc_pause_ns":  memStats.PauseNs,
+		sections = append(sections, "memory")
+	}
+	if sectionsArg, exists := args["sections"]; exists {
+		if list, ok := sectionsArg.([]interface{}); ok {
+			sections = sections[:0]
+			for _, item := range list {
+				if name, ok := item.(string); ok {
+					sections = append(sections, name)
+				}
+			}
 		}
 	}
 
-	// Return as JSON string for consistent output
-	jsonBytes, err := json.Marshal(info)
+	path, err := plugin.StringArg(args, "path", "/")
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal system info: %w", err)
+		return nil, err
+	}
+
+	// Collect each section independently so one failure or stall still
+	// returns the rest of the report
+	sectionErrors := make(map[string]string)
+	for _, section := range sections {
+		var value interface{}
+		var err error
+		switch section {
+		case "memory":
+			value, err = collectSection(ctx, 0, collectMemory)
+		case "disk":
+			value, err = collectSection(ctx, sectionTimeout, func() (interface{}, error) {
+				return collectDisk(path)
+			})
+		case "network":
+			value, err = collectSection(ctx, sectionTimeout, collectNetwork)
+		default:
+			err = fmt.Errorf("unknown section")
+		}
+
+		if err != nil {
+			sectionErrors[section] = err.Error()
+			continue
+		}
+		info[section] = value
+	}
+	if len(sectionErrors) > 0 {
+		info["errors"] = sectionErrors
+	}
+
+	return plugin.JSONResult(info), nil
+}
+
+// collectSection runs collect in its own goroutine and gives up when ctx is done
+// or, if timeout is non-zero, when the timeout expires. An abandoned collector
+// keeps running in the background until its syscall returns.
+func collectSection(ctx context.Context, timeout time.Duration, collect func() (interface{}, error)) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	return string(jsonBytes), nil
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type sectionResult struct {
+		value interface{}
+		err   error
+	}
+	done := make(chan sectionResult, 1)
+	go func() {
+		value, err := collect()
+		done <- sectionResult{value: value, err: err}
+	}()
+
+	select {
+	case result := <-done:
+		return result.value, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// collectMemory reports Go runtime memory statistics
+func collectMemory() (interface{}, error) {
+	// Shared with the metrics collector so frequent polling does not keep stopping the world
+	memStats, age := plugin.ReadMemStats()
+
+	return map[string]interface{}{
+		"alloc":        memStats.Alloc,
+		"total_alloc":  memStats.TotalAlloc,
+		"sys":          memStats.Sys,
+		"heap_alloc":   memStats.HeapAlloc,
+		"heap_sys":     memStats.HeapSys,
+		"heap_idle":    memStats.HeapIdle,
+		"heap_inuse":   memStats.HeapInuse,
+		"heap_objects": memStats.HeapObjects,
+		"gc_cycles":    memStats.NumGC,
+		"gc_pause_ns":  memStats.PauseNs,
+
+		"snapshot_age_ms": float64(age.Microseconds()) / 1000,
+	}, nil
+}
+
+// collectNetwork lists network interfaces and their addresses
+func collectNetwork() (interface{}, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network interfaces: %w", err)
+	}
+
+	interfaces := make([]map[string]interface{}, 0, len(ifaces))
+	for _, iface := range ifaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list addresses for %s: %w", iface.Name, err)
+		}
+
+		addresses := make([]string, 0, len(addrs))
+		for _, addr := range addrs {
+			addresses = append(addresses, addr.String())
+		}
+		interfaces = append(interfaces, map[string]interface{}{
+			"name":      iface.Name,
+			"mtu":       iface.MTU,
+			"flags":     iface.Flags.String(),
+			"mac":       iface.HardwareAddr.String(),
+			"addresses": addresses,
+		})
+	}
+	return interfaces, nil
 }
 
 // main function is required for plugin compilation but won't be used
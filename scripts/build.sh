@@ -112,9 +112,9 @@ build_app() {
     echo "构建目标: GOOS=$TARGET_OS GOARCH=$TARGET_ARCH"
     
     if [ "$TARGET_OS" = "linux" ]; then
-        CGO_ENABLED=0 GOOS="$TARGET_OS" GOARCH="$TARGET_ARCH" go build -a -installsuffix cgo -o bin/zephyr cmd/zephyr/main.go
+        CGO_ENABLED=0 GOOS="$TARGET_OS" GOARCH="$TARGET_ARCH" go build -a -installsuffix cgo ${BUILD_TAGS:+-tags "$BUILD_TAGS"} -o bin/zephyr cmd/zephyr/main.go
     else
-        GOOS="$TARGET_OS" GOARCH="$TARGET_ARCH" go build -o bin/zephyr cmd/zephyr/main.go
+        GOOS="$TARGET_OS" GOARCH="$TARGET_ARCH" go build ${BUILD_TAGS:+-tags "$BUILD_TAGS"} -o bin/zephyr cmd/zephyr/main.go
     fi
     
     echo "✅ 应用构建完成: bin/zephyr (平台: $TARGET_PLATFORM)"